@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/OldStager01/cloud-autoscaler/internal/collector"
 	"github.com/OldStager01/cloud-autoscaler/internal/scaler"
+	"github.com/OldStager01/cloud-autoscaler/internal/schedule"
 	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
 	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 	"github.com/OldStager01/cloud-autoscaler/pkg/validation"
@@ -19,20 +21,36 @@ import (
 type ClusterManager interface {
 	StartCluster(cluster *models.Cluster, coll collector.Collector, scal scaler.Scaler) error
 	StopCluster(clusterID string) error
+	RestartCluster(cluster *models.Cluster, coll collector.Collector) (bool, error)
+	ValidateCluster(cluster *models.Cluster) error
+	RecentDecisions(clusterID string, limit int) ([]*models.ScalingDecision, error)
+	ClusterServers(clusterID string) (*models.ServerReport, error)
+	Damping(clusterID string) (models.DampingState, error)
+	ScaleCluster(ctx context.Context, clusterID string, target, userID int) (*models.DecisionRecord, error)
+	PinCluster(clusterID string, pin models.ClusterPin) error
+	UnpinCluster(clusterID string) (bool, error)
+	ClusterPin(clusterID string) (*models.ClusterPin, error)
+	PendingScalingRequest(clusterID string) (*models.ScalingRequest, error)
+	ApproveScalingRequest(ctx context.Context, clusterID, requestID string, userID int) (*models.ScalingRequest, error)
+	RejectScalingRequest(ctx context.Context, clusterID, requestID string, userID int) (*models.ScalingRequest, error)
 	SubscribeAllEvents() <-chan *models.Event
 }
 
 type ClusterHandler struct {
 	clusterRepo    *queries.ClusterRepository
+	pinRepo        *queries.PinRepository
 	clusterManager ClusterManager
+	freezes        *schedule.FreezeChecker
 	simulatorURL   string
 	httpClient     *http.Client
 }
 
-func NewClusterHandler(clusterRepo *queries.ClusterRepository, clusterManager ClusterManager) *ClusterHandler {
+func NewClusterHandler(clusterRepo *queries.ClusterRepository, freezeRepo *queries.FreezeRepository, pinRepo *queries.PinRepository, clusterManager ClusterManager) *ClusterHandler {
 	return &ClusterHandler{
 		clusterRepo:    clusterRepo,
+		pinRepo:        pinRepo,
 		clusterManager: clusterManager,
+		freezes:        schedule.NewFreezeChecker(freezeRepo),
 		simulatorURL:   "http://localhost:9000",
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
@@ -91,6 +109,33 @@ func getUserID(c *gin.Context) (int, bool) {
 	return 0, false
 }
 
+// ownedCluster loads the cluster and checks the authenticated user owns it,
+// writing the error response when they don't
+func ownedCluster(ctx context.Context, c *gin.Context, clusterRepo *queries.ClusterRepository, clusterID string) (*models.Cluster, int, bool) {
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return nil, 0, false
+	}
+
+	cluster, err := clusterRepo.GetByID(ctx, clusterID)
+	if err != nil {
+		if err == queries.ErrClusterNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "cluster not found"})
+			return nil, 0, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify cluster ownership"})
+		return nil, 0, false
+	}
+
+	if cluster.UserID == nil || *cluster.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, 0, false
+	}
+
+	return cluster, userID, true
+}
+
 // List godoc
 // @Summary List clusters
 // @Description Get all clusters owned by the authenticated user
@@ -147,18 +192,10 @@ func (h *ClusterHandler) Get(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	userID, ok := getUserID(c)
+	cluster, _, ok := ownedCluster(ctx, c, h.clusterRepo, id)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
 		return
 	}
-
-	cluster, err := h.clusterRepo.GetByID(ctx, id)
-	if err != nil {
-		if err == queries.ErrClusterNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "cluster not found"})
-			return
-		}
 // Create godoc
 // @Summary Create cluster
 // @Description Create a new cluster for the authenticated user
@@ -172,15 +209,6 @@ func (h *ClusterHandler) Get(c *gin.Context) {
 // @Failure 409 {object} map[string]string "Cluster with this name already exists"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /clusters [post]
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cluster"})
-		return
-	}
-
-	// Check ownership
-	if cluster.UserID == nil || *cluster.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
-		return
-	}
 
 	c.JSON(http.StatusOK, toClusterResponse(cluster))
 }
@@ -205,6 +233,13 @@ func (h *ClusterHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if req.Config != nil {
+		if err := req.Config.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
@@ -226,6 +261,10 @@ func (h *ClusterHandler) Create(c *gin.Context) {
 	cluster := models.NewCluster(req.Name, req.MinServers, req.MaxServers, userID)
 	cluster.Config = req.Config
 
+	if !h.validateMerged(c, cluster) {
+		return
+	}
+
 	if err := h.clusterRepo.Create(ctx, cluster); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create cluster"})
 		return
@@ -236,11 +275,7 @@ func (h *ClusterHandler) Create(c *gin.Context) {
 		// Create cluster in simulator with correct server count
 		h.createInSimulator(cluster.ID, cluster.MinServers)
 
-		simulatorURL := "http://localhost:9000/metrics/" + cluster.ID
-		coll := collector.NewHTTPCollector(collector.HTTPCollectorConfig{
-			Endpoint: simulatorURL,
-			Timeout:  5 * time.Second,
-		})
+		coll := h.newCollector(cluster)
 
 		scal := scaler.NewSimulatorScaler(scaler.SimulatorConfig{
 			ProvisionTime: 3 * time.Second,
@@ -250,7 +285,7 @@ func (h *ClusterHandler) Create(c *gin.Context) {
 
 // Update godoc
 // @Summary Update cluster
-// @Description Update an existing cluster
+// @Description Update an existing cluster. Changing config, min_servers or max_servers restarts a running cluster's pipeline, so cooldowns start over and a pending scaling request expires.
 // @Tags Clusters
 // @Accept json
 // @Produce json
@@ -289,25 +324,8 @@ func (h *ClusterHandler) Update(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	userID, ok := getUserID(c)
+	cluster, _, ok := ownedCluster(ctx, c, h.clusterRepo, id)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
-		return
-	}
-
-	cluster, err := h.clusterRepo.GetByID(ctx, id)
-	if err != nil {
-		if err == queries.ErrClusterNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "cluster not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cluster"})
-		return
-	}
-
-	// Check ownership
-	if cluster.UserID == nil || *cluster.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
 		return
 	}
 
@@ -338,6 +356,10 @@ func (h *ClusterHandler) Update(c *gin.Context) {
 		cluster.Status = models.ClusterStatus(req.Status)
 	}
 	if req.Config != nil {
+		if err := req.Config.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		cluster.Config = req.Config
 	}
 
@@ -346,39 +368,63 @@ func (h *ClusterHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if !h.validateMerged(c, cluster) {
+		return
+	}
+
 	if err := h.clusterRepo.Update(ctx, cluster); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update cluster"})
 		return
 	}
 
+	if h.clusterManager == nil {
+		c.JSON(http.StatusOK, toClusterResponse(cluster))
+		return
+	}
+
+	// The pipeline is only replaced when the config it was built from changed;
+	// switching between observe and managed mode takes effect in place
+	if _, err := h.clusterManager.RestartCluster(cluster, h.newCollector(cluster)); err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"cluster": toClusterResponse(cluster),
+			"warning": "cluster updated but monitoring failed to restart: " + err.Error(),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, toClusterResponse(cluster))
 }
 
+// validateMerged rejects overrides that only conflict once merged with the
+// global config, which would otherwise stop the cluster's pipeline from starting
+func (h *ClusterHandler) validateMerged(c *gin.Context, cluster *models.Cluster) bool {
+	if h.clusterManager == nil {
+		return true
+	}
+	if err := h.clusterManager.ValidateCluster(cluster); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// newCollector builds the HTTP collector for the cluster's metrics endpoint,
+// the simulator's unless the cluster config names another
+func (h *ClusterHandler) newCollector(cluster *models.Cluster) collector.Collector {
+	return collector.NewHTTPCollector(collector.HTTPCollectorConfig{
+		Endpoint: cluster.MetricsEndpoint(h.simulatorURL + "/metrics"),
+		Timeout:  5 * time.Second,
+	})
+}
+
 func (h *ClusterHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	userID, ok := getUserID(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
-		return
-	}
-
 	// Check ownership before deleting
-	cluster, err := h.clusterRepo.GetByID(ctx, id)
-	if err != nil {
-		if err == queries.ErrClusterNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "cluster not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cluster"})
-		return
-	}
-
-	if cluster.UserID == nil || *cluster.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	if _, _, ok := ownedCluster(ctx, c, h.clusterRepo, id); !ok {
 		return
 	}
 
@@ -418,12 +464,14 @@ func (h *ClusterHandler) deleteFromSimulator(clusterID string) {
 }
 // GetStatus godoc
 // @Summary Get cluster status
-// @Description Get the current status and server counts for a cluster
+// @Description Get the current status, server counts, flap damping state, active pin, active freeze windows and pending scaling request for a cluster
 // @Tags Clusters
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Cluster ID"
-// @Success 200 {object} map[string]interface{} "Cluster status with server counts"
+// @Success 200 {object} map[string]interface{} "Cluster status with server counts and damping state"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
 // @Failure 404 {object} map[string]string "Cluster not found"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /clusters/{id}/status [get]
@@ -462,13 +510,8 @@ func (h *ClusterHandler) GetStatus(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	cluster, err := h.clusterRepo.GetByID(ctx, id)
-	if err != nil {
-		if err == queries.ErrClusterNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "cluster not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cluster"})
+	cluster, _, ok := ownedCluster(ctx, c, h.clusterRepo, id)
+	if !ok {
 		return
 	}
 
@@ -478,7 +521,7 @@ func (h *ClusterHandler) GetStatus(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"cluster_id":   cluster.ID,
 		"name":         cluster.Name,
 		"status":       cluster.Status,
@@ -488,5 +531,76 @@ func (h *ClusterHandler) GetStatus(c *gin.Context) {
 			"provisioning": serverCounts.Provisioning,
 			"draining":      serverCounts.Draining,
 		},
+	}
+
+	freezes, err := h.freezes.ActiveFreezes(ctx, id, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch freeze windows"})
+		return
+	}
+	response["freezes"] = freezes
+
+	// Damping, pins and pending requests are only known while the cluster's pipeline is running
+	if h.clusterManager != nil {
+		if damping, err := h.clusterManager.Damping(id); err == nil {
+			response["damping"] = damping
+		}
+		if pin, err := h.clusterManager.ClusterPin(id); err == nil && pin != nil {
+			response["pin"] = pin
+		}
+		if request, err := h.clusterManager.PendingScalingRequest(id); err == nil && request != nil {
+			response["pending_request"] = request
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDecisions godoc
+// @Summary Get recent scaling decisions
+// @Description Get the cluster's latest decisions, newest first, each with a trace of the checks, limits and cooldowns the engine evaluated
+// @Tags Clusters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param limit query int false "Max results (1-100)" default(20)
+// @Success 200 {object} map[string]interface{} "Recent decisions"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found or not running"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/decisions [get]
+func (h *ClusterHandler) GetDecisions(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, _, ok := ownedCluster(ctx, c, h.clusterRepo, id); !ok {
+		return
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	if h.clusterManager == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+	decisions, err := h.clusterManager.RecentDecisions(id, limit)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cluster_id": id,
+		"decisions":  decisions,
+		"count":      len(decisions),
 	})
-}
\ No newline at end of file
+}
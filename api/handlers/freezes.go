@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/schedule"
+	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+	"github.com/OldStager01/cloud-autoscaler/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type FreezeHandler struct {
+	freezeRepo  *queries.FreezeRepository
+	clusterRepo *queries.ClusterRepository
+}
+
+func NewFreezeHandler(freezeRepo *queries.FreezeRepository, clusterRepo *queries.ClusterRepository) *FreezeHandler {
+	return &FreezeHandler{
+		freezeRepo:  freezeRepo,
+		clusterRepo: clusterRepo,
+	}
+}
+
+// FreezeRequest creates or replaces a freeze window. Omit cluster_id to freeze all of
+// the user's clusters. Set starts_at and ends_at for a one-off window, or cron and
+// duration_minutes for a recurring one.
+type FreezeRequest struct {
+	Name            string     `json:"name" binding:"required,min=1,max=100" example:"release-train"`
+	ClusterID       *string    `json:"cluster_id,omitempty"`
+	Scope           string     `json:"scope" example:"scale_down"`
+	AllowEmergency  bool       `json:"allow_emergency" example:"true"`
+	StartsAt        *time.Time `json:"starts_at,omitempty" example:"2024-01-15T22:00:00Z"`
+	EndsAt          *time.Time `json:"ends_at,omitempty" example:"2024-01-16T02:00:00Z"`
+	Cron            string     `json:"cron,omitempty" example:"0 22 * * TUE"`
+	Timezone        string     `json:"timezone,omitempty" example:"Europe/Berlin"`
+	DurationMinutes int        `json:"duration_minutes,omitempty" binding:"omitempty,min=1" example:"120"`
+	Reason          string     `json:"reason,omitempty" example:"weekly database migration"`
+	Enabled         *bool      `json:"enabled,omitempty" example:"true"`
+}
+
+// apply copies the request onto a freeze window and validates the result
+func (r *FreezeRequest) apply(f *models.FreezeWindow) error {
+	f.Name = validation.SanitizeString(r.Name)
+	f.ClusterID = r.ClusterID
+	f.Scope = models.FreezeScope(r.Scope)
+	if f.Scope == "" {
+		f.Scope = models.FreezeAll
+	}
+	f.AllowEmergency = r.AllowEmergency
+	f.StartsAt = r.StartsAt
+	f.EndsAt = r.EndsAt
+	f.Cron = r.Cron
+	f.Timezone = r.Timezone
+	if f.Timezone == "" && f.Cron != "" {
+		f.Timezone = "UTC"
+	}
+	f.DurationMinutes = r.DurationMinutes
+	f.Reason = validation.SanitizeString(r.Reason)
+	f.Enabled = r.Enabled == nil || *r.Enabled
+
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	if !f.IsRecurring() {
+		return nil
+	}
+	_, err := schedule.Parse(f.Cron)
+	return err
+}
+
+// List godoc
+// @Summary List freeze windows
+// @Description Get the user's freeze windows, optionally only those that apply to one cluster
+// @Tags Freezes
+// @Produce json
+// @Security BearerAuth
+// @Param cluster_id query string false "Only freezes that apply to this cluster"
+// @Success 200 {object} map[string]interface{} "List of freeze windows"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /freezes [get]
+func (h *FreezeHandler) List(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	clusterID := c.Query("cluster_id")
+	if clusterID != "" {
+		if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	freezes, err := h.freezeRepo.GetByUser(ctx, userID, clusterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch freeze windows"})
+		return
+	}
+	if freezes == nil {
+		freezes = []*models.FreezeWindow{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"freezes": freezes,
+		"count":   len(freezes),
+	})
+}
+
+// Get godoc
+// @Summary Get freeze window
+// @Description Get a single freeze window
+// @Tags Freezes
+// @Produce json
+// @Security BearerAuth
+// @Param freeze_id path string true "Freeze window ID"
+// @Success 200 {object} models.FreezeWindow "Freeze window details"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 404 {object} map[string]string "Freeze window not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /freezes/{freeze_id} [get]
+func (h *FreezeHandler) Get(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	f, err := h.freezeRepo.GetByID(ctx, userID, c.Param("freeze_id"))
+	if err != nil {
+		if err == queries.ErrFreezeNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "freeze window not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch freeze window"})
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
+
+// Create godoc
+// @Summary Create freeze window
+// @Description Add a one-off or recurring window that blocks scale-down, or all scaling, for a cluster or for all of the user's clusters. Blocked decisions are recorded as suppressed with reason freeze_active.
+// @Tags Freezes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body FreezeRequest true "Freeze window details"
+// @Success 201 {object} models.FreezeWindow "Freeze window created successfully"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /freezes [post]
+func (h *FreezeHandler) Create(c *gin.Context) {
+	var req FreezeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	f := models.NewFreezeWindow(userID, req.Name)
+	if err := req.apply(f); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Freezes without a cluster cover all of the user's clusters and need no check
+	if f.ClusterID != nil {
+		if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, *f.ClusterID); !ok {
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.freezeRepo.Create(ctx, f); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create freeze window"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, f)
+}
+
+// Update godoc
+// @Summary Update freeze window
+// @Description Replace a freeze window. Pipelines pick up the change on their next cycle.
+// @Tags Freezes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param freeze_id path string true "Freeze window ID"
+// @Param request body FreezeRequest true "Freeze window details"
+// @Success 200 {object} models.FreezeWindow "Freeze window updated successfully"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster or freeze window not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /freezes/{freeze_id} [put]
+func (h *FreezeHandler) Update(c *gin.Context) {
+	var req FreezeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	f, err := h.freezeRepo.GetByID(ctx, userID, c.Param("freeze_id"))
+	if err != nil {
+		if err == queries.ErrFreezeNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "freeze window not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch freeze window"})
+		return
+	}
+
+	if err := req.apply(f); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if f.ClusterID != nil {
+		if _, _, ok := ownedCluster(ctx, c, h.clusterRepo, *f.ClusterID); !ok {
+			return
+		}
+	}
+
+	if err := h.freezeRepo.Update(ctx, f); err != nil {
+		if err == queries.ErrFreezeNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "freeze window not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update freeze window"})
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
+
+// Delete godoc
+// @Summary Delete freeze window
+// @Description Delete a freeze window. Scaling resumes on the affected clusters' next cycle.
+// @Tags Freezes
+// @Produce json
+// @Security BearerAuth
+// @Param freeze_id path string true "Freeze window ID"
+// @Success 200 {object} map[string]string "Freeze window deleted successfully"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 404 {object} map[string]string "Freeze window not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /freezes/{freeze_id} [delete]
+func (h *FreezeHandler) Delete(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.freezeRepo.Delete(ctx, userID, c.Param("freeze_id")); err != nil {
+		if err == queries.ErrFreezeNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "freeze window not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete freeze window"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "freeze window deleted"})
+}
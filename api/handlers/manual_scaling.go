@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Longest a single pin may hold a cluster
+const maxPinDuration = 7 * 24 * time.Hour
+
+type ScaleClusterRequest struct {
+	Servers int `json:"servers" binding:"required,min=1" example:"12"`
+}
+
+// PinClusterRequest holds the cluster at Servers until Until, or for DurationMinutes
+// from now. Exactly one of the two must be set.
+type PinClusterRequest struct {
+	Servers         int        `json:"servers" binding:"required,min=1" example:"12"`
+	Until           *time.Time `json:"until" example:"2024-01-15T12:30:00Z"`
+	DurationMinutes int        `json:"duration_minutes" binding:"omitempty,min=1" example:"120"`
+}
+
+// expiry resolves the pin's end time relative to now
+func (r *PinClusterRequest) expiry(now time.Time) (time.Time, error) {
+	if (r.Until == nil) == (r.DurationMinutes == 0) {
+		return time.Time{}, fmt.Errorf("exactly one of until or duration_minutes is required")
+	}
+
+	until := now.Add(time.Duration(r.DurationMinutes) * time.Minute)
+	if r.Until != nil {
+		until = *r.Until
+	}
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("until must be in the future")
+	}
+	if until.Sub(now) > maxPinDuration {
+		return time.Time{}, fmt.Errorf("a pin may last at most %s", maxPinDuration)
+	}
+	return until, nil
+}
+
+// checkManualTarget rejects a manual server count the cluster can't take
+func checkManualTarget(c *gin.Context, cluster *models.Cluster, servers int) bool {
+	if cluster.IsObserveOnly() {
+		c.JSON(http.StatusConflict, gin.H{"error": "cluster is in observe mode"})
+		return false
+	}
+	if servers < cluster.MinServers || servers > cluster.MaxServers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf(
+			"servers must be between min_servers (%d) and max_servers (%d)", cluster.MinServers, cluster.MaxServers,
+		)})
+		return false
+	}
+	return true
+}
+
+// Scale godoc
+// @Summary Scale cluster manually
+// @Description Scale a running cluster to an explicit server count once. The resulting scaling event is attributed to the authenticated user.
+// @Tags Clusters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param request body ScaleClusterRequest true "Target server count"
+// @Success 200 {object} models.DecisionRecord "Decision and its outcome"
+// @Failure 400 {object} map[string]string "Invalid request body or server count outside the cluster's limits"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found or not running"
+// @Failure 409 {object} map[string]string "Cluster is in observe mode or pinned"
+// @Failure 500 {object} map[string]string "Scaling failed"
+// @Router /clusters/{id}/scale [post]
+func (h *ClusterHandler) Scale(c *gin.Context) {
+	id := c.Param("id")
+
+	var req ScaleClusterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	cluster, userID, ok := ownedCluster(ctx, c, h.clusterRepo, id)
+	if !ok {
+		return
+	}
+	if !checkManualTarget(c, cluster, req.Servers) {
+		return
+	}
+
+	if h.clusterManager == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+	pin, err := h.clusterManager.ClusterPin(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+	if pin != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "cluster is pinned, release the pin first"})
+		return
+	}
+
+	record, err := h.clusterManager.ScaleCluster(ctx, id, req.Servers, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "scaling failed: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// Pin godoc
+// @Summary Pin cluster size
+// @Description Hold a running cluster at an explicit server count until an expiry time. Automatic scaling decisions are skipped while the pin is active; scaling towards the pinned count is attributed to the authenticated user.
+// @Tags Clusters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param request body PinClusterRequest true "Pinned server count and expiry"
+// @Success 200 {object} models.ClusterPin "Active pin"
+// @Failure 400 {object} map[string]string "Invalid request body or server count outside the cluster's limits"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found or not running"
+// @Failure 409 {object} map[string]string "Cluster is in observe mode"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/pin [post]
+func (h *ClusterHandler) Pin(c *gin.Context) {
+	id := c.Param("id")
+
+	var req PinClusterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	until, err := req.expiry(now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	cluster, userID, ok := ownedCluster(ctx, c, h.clusterRepo, id)
+	if !ok {
+		return
+	}
+	if !checkManualTarget(c, cluster, req.Servers) {
+		return
+	}
+
+	pin := models.ClusterPin{
+		DesiredServers: req.Servers,
+		Until:          until,
+		PinnedBy:       userID,
+		PinnedAt:       now,
+	}
+	if h.clusterManager == nil || h.clusterManager.PinCluster(id, pin) != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+
+	// Stored so the pin is restored when the pipeline restarts
+	if err := h.pinRepo.Save(ctx, id, pin); err != nil {
+		_, _ = h.clusterManager.UnpinCluster(id)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save pin"})
+		return
+	}
+
+	c.JSON(http.StatusOK, pin)
+}
+
+// Unpin godoc
+// @Summary Release cluster pin
+// @Description Release a cluster's pin so automatic scaling resumes
+// @Tags Clusters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Success 200 {object} map[string]string "Pin released"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found, not running or not pinned"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/pin [delete]
+func (h *ClusterHandler) Unpin(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, _, ok := ownedCluster(ctx, c, h.clusterRepo, id); !ok {
+		return
+	}
+
+	if h.clusterManager == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+	released, err := h.clusterManager.UnpinCluster(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+	if err := h.pinRepo.Delete(ctx, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete pin"})
+		return
+	}
+	if !released {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not pinned"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "pin released"})
+}
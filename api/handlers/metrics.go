@@ -7,51 +7,30 @@ import (
 
 	"github.com/OldStager01/cloud-autoscaler/pkg/config"
 	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 	"github.com/gin-gonic/gin"
 )
 
 type MetricsHandler struct {
-	metricsRepo *queries.MetricsRepository
-	eventsRepo  *queries.ScalingEventRepository
-	clusterRepo *queries.ClusterRepository
-	config      *config.APIConfig
+	metricsRepo     *queries.MetricsRepository
+	eventsRepo      *queries.ScalingEventRepository
+	predictionsRepo *queries.PredictionRepository
+	decisionsRepo   *queries.DecisionRepository
+	clusterRepo     *queries.ClusterRepository
+	config          *config.APIConfig
 }
 
-func NewMetricsHandler(metricsRepo *queries.MetricsRepository, eventsRepo *queries.ScalingEventRepository, clusterRepo *queries.ClusterRepository, cfg *config.APIConfig) *MetricsHandler {
+func NewMetricsHandler(metricsRepo *queries.MetricsRepository, eventsRepo *queries.ScalingEventRepository, predictionsRepo *queries.PredictionRepository, decisionsRepo *queries.DecisionRepository, clusterRepo *queries.ClusterRepository, cfg *config.APIConfig) *MetricsHandler {
 	return &MetricsHandler{
-		metricsRepo: metricsRepo,
-		eventsRepo:  eventsRepo,
-		clusterRepo: clusterRepo,
-		config:      cfg,
+		metricsRepo:     metricsRepo,
+		eventsRepo:      eventsRepo,
+		predictionsRepo: predictionsRepo,
+		decisionsRepo:   decisionsRepo,
+		clusterRepo:     clusterRepo,
+		config:          cfg,
 	}
 }
 
-// verifyClusterOwnership checks if the authenticated user owns the cluster
-func (h *MetricsHandler) verifyClusterOwnership(c *gin.Context, clusterID string) bool {
-	userID, ok := getUserID(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
-		return false
-	}
-
-	cluster, err := h.clusterRepo.GetByID(c.Request.Context(), clusterID)
-	if err != nil {
-		if err == queries.ErrClusterNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "cluster not found"})
-			return false
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify cluster ownership"})
-		return false
-	}
-
-	if cluster.UserID == nil || *cluster.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
-		return false
-	}
-
-	return true
-}
-
 func (h *MetricsHandler) getDefaultLimit() int {
 	if h.config != nil && h.config.DefaultLimit > 0 {
 		return h.config.DefaultLimit
@@ -88,7 +67,7 @@ func (h *MetricsHandler) getMaxLimit() int {
 func (h *MetricsHandler) GetMetrics(c *gin.Context) {
 	clusterID := c.Param("id")
 
-	if !h.verifyClusterOwnership(c, clusterID) {
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
 		return
 	}
 
@@ -135,7 +114,7 @@ func (h *MetricsHandler) GetMetrics(c *gin.Context) {
 
 // GetLatestMetrics godoc
 // @Summary Get latest metrics
-// @Description Get the most recent metrics for a cluster
+// @Description Get the most recent metrics for a cluster, including CPU percentiles across servers and a summary of each custom metric
 // @Tags Metrics
 // @Produce json
 // @Security BearerAuth
@@ -149,7 +128,7 @@ func (h *MetricsHandler) GetMetrics(c *gin.Context) {
 func (h *MetricsHandler) GetLatestMetrics(c *gin.Context) {
 	clusterID := c.Param("id")
 
-	if !h.verifyClusterOwnership(c, clusterID) {
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
 		return
 	}
 
@@ -171,7 +150,7 @@ func (h *MetricsHandler) GetLatestMetrics(c *gin.Context) {
 
 // GetHourlyMetrics godoc
 // @Summary Get hourly metrics
-// @Description Get hourly aggregated metrics for a cluster
+// @Description Get hourly aggregated metrics for a cluster, with CPU percentiles for hours whose raw metrics are still retained
 // @Tags Metrics
 // @Produce json
 // @Security BearerAuth
@@ -188,7 +167,7 @@ func (h *MetricsHandler) GetLatestMetrics(c *gin.Context) {
 func (h *MetricsHandler) GetHourlyMetrics(c *gin.Context) {
 	clusterID := c.Param("id")
 
-	if !h.verifyClusterOwnership(c, clusterID) {
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
 		return
 	}
 
@@ -210,6 +189,57 @@ func (h *MetricsHandler) GetHourlyMetrics(c *gin.Context) {
 	})
 }
 
+// GetCustomMetrics godoc
+// @Summary Get custom metrics
+// @Description Get a cluster's service-exported custom metrics, such as queue depth or latency, aggregated into time buckets
+// @Tags Metrics
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param name query string false "Only return this metric" example:"queue_depth"
+// @Param from query string false "Start time (RFC3339 format)"
+// @Param to query string false "End time (RFC3339 format)"
+// @Param range query string false "Relative time range (e.g., 1h, 24h, 7d)"
+// @Param bucket query int false "Aggregation bucket size in minutes" default(5)
+// @Success 200 {object} map[string]interface{} "Custom metrics data"
+// @Failure 400 {object} map[string]string "Invalid metric name"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/metrics/custom [get]
+func (h *MetricsHandler) GetCustomMetrics(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	name := c.Query("name")
+	if name != "" && !models.ValidCustomMetricName(name) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid metric name"})
+		return
+	}
+
+	from, to := h.parseTimeRange(c)
+	bucketMinutes := h.parseInt(c.Query("bucket"), 5)
+
+	metrics, err := h.metricsRepo.GetCustomAggregated(c.Request.Context(), clusterID, name, from, to, bucketMinutes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch custom metrics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cluster_id":     clusterID,
+		"from":           from,
+		"to":             to,
+		"bucket_minutes": bucketMinutes,
+		"data":           metrics,
+		"count":          len(metrics),
+	})
+}
+
 // GetScalingEvents godoc
 // @Summary Get scaling events
 // @Description Get scaling events for a cluster
@@ -230,7 +260,7 @@ func (h *MetricsHandler) GetHourlyMetrics(c *gin.Context) {
 func (h *MetricsHandler) GetScalingEvents(c *gin.Context) {
 	clusterID := c.Param("id")
 
-	if !h.verifyClusterOwnership(c, clusterID) {
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
 		return
 	}
 
@@ -283,7 +313,7 @@ func (h *MetricsHandler) GetScalingStats(c *gin.Context) {
 // @Failure 401 {object} map[string]string "User not authenticated"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /events/recent [get]
-	if !h.verifyClusterOwnership(c, clusterID) {
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
 		return
 	}
 
@@ -321,6 +351,238 @@ func (h *MetricsHandler) GetRecentEvents(c *gin.Context) {
 	})
 }
 
+// GetPredictions godoc
+// @Summary Get predictions
+// @Description Get recorded forecasts for a cluster, including observed CPU once known. The member forecasts of an ensemble are only reported by the accuracy endpoint.
+// @Tags Predictions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param from query string false "Start time (RFC3339 format)"
+// @Param to query string false "End time (RFC3339 format)"
+// @Param range query string false "Relative time range (e.g., 1h, 24h, 7d)"
+// @Param limit query int false "Maximum number of results" default(100)
+// @Success 200 {object} map[string]interface{} "Predictions"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/predictions [get]
+func (h *MetricsHandler) GetPredictions(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	from, to := h.parseTimeRange(c)
+	limit := h.parseLimit(c, h.getDefaultLimit())
+	ctx := c.Request.Context()
+
+	predictions, err := h.predictionsRepo.GetByCluster(ctx, clusterID, from, to, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch predictions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cluster_id": clusterID,
+		"from":       from,
+		"to":         to,
+		"data":       predictions,
+		"count":      len(predictions),
+	})
+}
+
+// GetPredictionAccuracy godoc
+// @Summary Get prediction accuracy
+// @Description Get MAE, MAPE and hit rate per model version for forecasts whose outcome is known
+// @Tags Predictions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param from query string false "Start time (RFC3339 format)"
+// @Param to query string false "End time (RFC3339 format)"
+// @Param range query string false "Relative time range (e.g., 1h, 24h, 7d)"
+// @Param tolerance query number false "Hit tolerance in CPU percentage points" default(5)
+// @Success 200 {object} map[string]interface{} "Accuracy per model version"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/predictions/accuracy [get]
+func (h *MetricsHandler) GetPredictionAccuracy(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	from, to := h.parseTimeRange(c)
+	tolerance := 5.0
+	if toleranceStr := c.Query("tolerance"); toleranceStr != "" {
+		if parsed, err := strconv.ParseFloat(toleranceStr, 64); err == nil && parsed > 0 {
+			tolerance = parsed
+		}
+	}
+	ctx := c.Request.Context()
+
+	accuracy, err := h.predictionsRepo.GetAccuracy(ctx, clusterID, from, to, tolerance)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch prediction accuracy"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cluster_id": clusterID,
+		"from":       from,
+		"to":         to,
+		"tolerance":  tolerance,
+		"data":       accuracy,
+		"count":      len(accuracy),
+	})
+}
+
+// GetDecisionHistory godoc
+// @Summary Get decision history
+// @Description Get persisted scaling decisions for a cluster, including MAINTAIN and cooldown-suppressed ones
+// @Tags Decisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param action query string false "Filter by action (SCALE_UP, SCALE_DOWN, MAINTAIN)"
+// @Param reason query string false "Filter by decision reason"
+// @Param outcome query string false "Filter by outcome (maintained, cooldown_suppressed, executed, partial, failed, observed, freeze_suppressed, pending_approval, data_quality_blocked)"
+// @Param from query string false "Start time (RFC3339 format)"
+// @Param to query string false "End time (RFC3339 format)"
+// @Param range query string false "Relative time range (e.g., 1h, 24h, 7d)"
+// @Param limit query int false "Maximum number of results" default(100)
+// @Success 200 {object} map[string]interface{} "Decision history"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/decisions/history [get]
+func (h *MetricsHandler) GetDecisionHistory(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	action := models.ScalingAction(c.Query("action"))
+	switch action {
+	case "", models.ActionScaleUp, models.ActionScaleDown, models.ActionMaintain:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid action"})
+		return
+	}
+
+	outcome := models.DecisionOutcome(c.Query("outcome"))
+	switch outcome {
+	case "", models.OutcomeMaintained, models.OutcomeSuppressed, models.OutcomeExecuted,
+		models.OutcomePartial, models.OutcomeFailed, models.OutcomeObserved, models.OutcomeFrozen, models.OutcomePendingApproval,
+		models.OutcomeDataQualityBlocked:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid outcome"})
+		return
+	}
+
+	from, to := h.parseTimeRange(c)
+	filter := queries.DecisionFilter{
+		ClusterID: clusterID,
+		Action:    string(action),
+		Reason:    c.Query("reason"),
+		Outcome:   string(outcome),
+		From:      from,
+		To:        to,
+		Limit:     h.parseLimit(c, h.getDefaultLimit()),
+	}
+
+	decisions, err := h.decisionsRepo.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch decision history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cluster_id": clusterID,
+		"from":       from,
+		"to":         to,
+		"data":       decisions,
+		"count":      len(decisions),
+	})
+}
+
+// GetDecisionStats godoc
+// @Summary Get decision statistics
+// @Description Count a cluster's persisted decisions by action, outcome and reason
+// @Tags Decisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param from query string false "Start time (RFC3339 format)"
+// @Param to query string false "End time (RFC3339 format)"
+// @Param range query string false "Relative time range (e.g., 1h, 24h, 7d)"
+// @Success 200 {object} map[string]interface{} "Decision statistics"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/decisions/stats [get]
+func (h *MetricsHandler) GetDecisionStats(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	from, to := h.parseTimeRange(c)
+
+	stats, err := h.decisionsRepo.GetStats(c.Request.Context(), clusterID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch decision stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetObserveReport godoc
+// @Summary Get observe-mode report
+// @Description Summarise the scaling actions a cluster in observe mode would have taken, by reason and per hour
+// @Tags Decisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param from query string false "Start time (RFC3339 format)"
+// @Param to query string false "End time (RFC3339 format)"
+// @Param range query string false "Relative time range (e.g., 1h, 24h, 7d)"
+// @Success 200 {object} map[string]interface{} "Observe-mode report"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/observe/report [get]
+func (h *MetricsHandler) GetObserveReport(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	from, to := h.parseTimeRange(c)
+
+	report, err := h.decisionsRepo.GetObservedReport(c.Request.Context(), clusterID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build observe report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 func (h *MetricsHandler) parseTimeRange(c *gin.Context) (time.Time, time.Time) {
 	to := time.Now()
 	from := to.Add(-1 * time.Hour) // Default:  last hour
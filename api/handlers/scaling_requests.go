@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/orchestrator"
+	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+	"github.com/gin-gonic/gin"
+)
+
+type ScalingRequestHandler struct {
+	requestRepo    *queries.ScalingRequestRepository
+	clusterRepo    *queries.ClusterRepository
+	clusterManager ClusterManager
+}
+
+func NewScalingRequestHandler(requestRepo *queries.ScalingRequestRepository, clusterRepo *queries.ClusterRepository, clusterManager ClusterManager) *ScalingRequestHandler {
+	return &ScalingRequestHandler{
+		requestRepo:    requestRepo,
+		clusterRepo:    clusterRepo,
+		clusterManager: clusterManager,
+	}
+}
+
+// ownedRequest loads a scaling request and checks the authenticated user owns its
+// cluster, writing the error response when they don't
+func (h *ScalingRequestHandler) ownedRequest(ctx context.Context, c *gin.Context) (*models.ScalingRequest, int, bool) {
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return nil, 0, false
+	}
+
+	request, err := h.requestRepo.GetByID(ctx, c.Param("id"))
+	if err != nil {
+		if err == queries.ErrScalingRequestNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "scaling request not found"})
+			return nil, 0, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch scaling request"})
+		return nil, 0, false
+	}
+
+	cluster, err := h.clusterRepo.GetByID(ctx, request.ClusterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify cluster ownership"})
+		return nil, 0, false
+	}
+	if cluster.UserID == nil || *cluster.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, 0, false
+	}
+
+	return request, userID, true
+}
+
+// List godoc
+// @Summary List scaling requests
+// @Description Get the scale-ups that waited, or still wait, for approval on the user's clusters, newest first
+// @Tags Scaling Requests
+// @Produce json
+// @Security BearerAuth
+// @Param cluster_id query string false "Filter by cluster"
+// @Param status query string false "Filter by status (pending, approved, rejected, expired)"
+// @Param limit query int false "Max results (1-100)" default(50)
+// @Success 200 {object} map[string]interface{} "List of scaling requests"
+// @Failure 400 {object} map[string]string "Invalid status"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /scaling-requests [get]
+func (h *ScalingRequestHandler) List(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	filter := queries.ScalingRequestFilter{
+		UserID:    userID,
+		ClusterID: c.Query("cluster_id"),
+		Status:    c.Query("status"),
+		Limit:     50,
+	}
+	switch models.ScalingRequestStatus(filter.Status) {
+	case "", models.ScalingRequestPending, models.ScalingRequestApproved,
+		models.ScalingRequestRejected, models.ScalingRequestExpired:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of: pending, approved, rejected, expired"})
+		return
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		filter.Limit = l
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	requests, err := h.requestRepo.List(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch scaling requests"})
+		return
+	}
+	if requests == nil {
+		requests = []*models.ScalingRequest{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scaling_requests": requests,
+		"count":            len(requests),
+	})
+}
+
+// Get godoc
+// @Summary Get scaling request
+// @Description Get a single scaling request
+// @Tags Scaling Requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Scaling request ID"
+// @Success 200 {object} models.ScalingRequest "Scaling request details"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Scaling request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /scaling-requests/{id} [get]
+func (h *ScalingRequestHandler) Get(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	request, _, ok := h.ownedRequest(ctx, c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// Approve godoc
+// @Summary Approve scaling request
+// @Description Execute a pending scale-up. The cluster scales to the requested size, counting capacity that arrived in the meantime, and the scaling event is attributed to the authenticated user.
+// @Tags Scaling Requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Scaling request ID"
+// @Success 200 {object} models.ScalingRequest "Approved request with its outcome"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Scaling request not found"
+// @Failure 409 {object} map[string]string "Scaling request is no longer pending"
+// @Failure 500 {object} map[string]string "Scaling failed"
+// @Router /scaling-requests/{id}/approve [post]
+func (h *ScalingRequestHandler) Approve(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	request, userID, ok := h.ownedRequest(ctx, c)
+	if !ok {
+		return
+	}
+	if h.clusterManager == nil || request.Status != models.ScalingRequestPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "scaling request is no longer pending"})
+		return
+	}
+
+	approved, err := h.clusterManager.ApproveScalingRequest(ctx, request.ClusterID, request.ID, userID)
+	if err != nil {
+		if errors.Is(err, orchestrator.ErrRequestNotPending) {
+			c.JSON(http.StatusConflict, gin.H{"error": "scaling request is no longer pending"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "scaling failed: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, approved)
+}
+
+// Reject godoc
+// @Summary Reject scaling request
+// @Description Drop a pending scale-up without scaling. The engine may request it again if load stays high.
+// @Tags Scaling Requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Scaling request ID"
+// @Success 200 {object} models.ScalingRequest "Rejected request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Scaling request not found"
+// @Failure 409 {object} map[string]string "Scaling request is no longer pending"
+// @Router /scaling-requests/{id}/reject [post]
+func (h *ScalingRequestHandler) Reject(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	request, userID, ok := h.ownedRequest(ctx, c)
+	if !ok {
+		return
+	}
+	if h.clusterManager == nil || request.Status != models.ScalingRequestPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "scaling request is no longer pending"})
+		return
+	}
+
+	rejected, err := h.clusterManager.RejectScalingRequest(ctx, request.ClusterID, request.ID, userID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "scaling request is no longer pending"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rejected)
+}
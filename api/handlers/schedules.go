@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/schedule"
+	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+	"github.com/OldStager01/cloud-autoscaler/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleHandler struct {
+	scheduleRepo *queries.ScheduleRepository
+	clusterRepo  *queries.ClusterRepository
+}
+
+func NewScheduleHandler(scheduleRepo *queries.ScheduleRepository, clusterRepo *queries.ClusterRepository) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleRepo: scheduleRepo,
+		clusterRepo:  clusterRepo,
+	}
+}
+
+// ScheduleRequest creates or replaces a scaling schedule
+type ScheduleRequest struct {
+	Name            string `json:"name" binding:"required,min=1,max=100" example:"weekday-business-hours"`
+	Cron            string `json:"cron" binding:"required" example:"0 8 * * MON-FRI"`
+	Timezone        string `json:"timezone" example:"Europe/Berlin"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=1" example:"600"`
+	MinServers      *int   `json:"min_servers,omitempty" example:"6"`
+	MaxServers      *int   `json:"max_servers,omitempty" example:"20"`
+	DesiredServers  *int   `json:"desired_servers,omitempty"`
+	Enabled         *bool  `json:"enabled,omitempty" example:"true"`
+}
+
+// apply copies the request onto a schedule and validates the result
+func (r *ScheduleRequest) apply(s *models.ScalingSchedule) error {
+	s.Name = validation.SanitizeString(r.Name)
+	s.Cron = r.Cron
+	s.Timezone = r.Timezone
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+	s.DurationMinutes = r.DurationMinutes
+	s.MinServers = r.MinServers
+	s.MaxServers = r.MaxServers
+	s.DesiredServers = r.DesiredServers
+	s.Enabled = r.Enabled == nil || *r.Enabled
+
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	_, err := schedule.Parse(s.Cron)
+	return err
+}
+
+// nameTaken reports whether another schedule of the cluster already uses the name
+func (h *ScheduleHandler) nameTaken(ctx context.Context, clusterID, name, excludeID string) (bool, error) {
+	schedules, err := h.scheduleRepo.GetByCluster(ctx, clusterID)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range schedules {
+		if s.Name == name && s.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// List godoc
+// @Summary List scaling schedules
+// @Description Get the cron-based scaling schedules of a cluster
+// @Tags Schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Success 200 {object} map[string]interface{} "List of schedules"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/schedules [get]
+func (h *ScheduleHandler) List(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	schedules, err := h.scheduleRepo.GetByCluster(ctx, clusterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch schedules"})
+		return
+	}
+	if schedules == nil {
+		schedules = []*models.ScalingSchedule{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cluster_id": clusterID,
+		"schedules":  schedules,
+		"count":      len(schedules),
+	})
+}
+
+// Get godoc
+// @Summary Get scaling schedule
+// @Description Get a single scaling schedule of a cluster
+// @Tags Schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param schedule_id path string true "Schedule ID"
+// @Success 200 {object} models.ScalingSchedule "Schedule details"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster or schedule not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/schedules/{schedule_id} [get]
+func (h *ScheduleHandler) Get(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	s, err := h.scheduleRepo.GetByID(ctx, clusterID, c.Param("schedule_id"))
+	if err != nil {
+		if err == queries.ErrScheduleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// Create godoc
+// @Summary Create scaling schedule
+// @Description Add a cron-based schedule that temporarily overrides the cluster's server limits
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param request body ScheduleRequest true "Schedule details"
+// @Success 201 {object} models.ScalingSchedule "Schedule created successfully"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found"
+// @Failure 409 {object} map[string]string "Schedule with this name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/schedules [post]
+func (h *ScheduleHandler) Create(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	s := models.NewScalingSchedule(clusterID, req.Name)
+	if err := req.apply(s); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	taken, err := h.nameTaken(ctx, clusterID, s.Name, "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create schedule"})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "schedule with this name already exists"})
+		return
+	}
+
+	if err := h.scheduleRepo.Create(ctx, s); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create schedule"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, s)
+}
+
+// Update godoc
+// @Summary Update scaling schedule
+// @Description Replace a scaling schedule. Changes take effect at the next scheduler run.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param schedule_id path string true "Schedule ID"
+// @Param request body ScheduleRequest true "Schedule details"
+// @Success 200 {object} models.ScalingSchedule "Schedule updated successfully"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster or schedule not found"
+// @Failure 409 {object} map[string]string "Schedule with this name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/schedules/{schedule_id} [put]
+func (h *ScheduleHandler) Update(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	s, err := h.scheduleRepo.GetByID(ctx, clusterID, c.Param("schedule_id"))
+	if err != nil {
+		if err == queries.ErrScheduleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch schedule"})
+		return
+	}
+
+	if err := req.apply(s); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taken, err := h.nameTaken(ctx, clusterID, s.Name, s.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update schedule"})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "schedule with this name already exists"})
+		return
+	}
+
+	if err := h.scheduleRepo.Update(ctx, s); err != nil {
+		if err == queries.ErrScheduleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// Delete godoc
+// @Summary Delete scaling schedule
+// @Description Delete a scaling schedule. An open window ends at the next scheduler run.
+// @Tags Schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Param schedule_id path string true "Schedule ID"
+// @Success 200 {object} map[string]string "Schedule deleted successfully"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster or schedule not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/schedules/{schedule_id} [delete]
+func (h *ScheduleHandler) Delete(c *gin.Context) {
+	clusterID := c.Param("id")
+
+	if _, _, ok := ownedCluster(c.Request.Context(), c, h.clusterRepo, clusterID); !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.scheduleRepo.Delete(ctx, clusterID, c.Param("schedule_id")); err != nil {
+		if err == queries.ErrScheduleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "schedule deleted"})
+}
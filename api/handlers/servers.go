@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetServers godoc
+// @Summary Get per-server view
+// @Description Get each server's latest metrics from the cluster's last collection, with hot and stale servers flagged and the spread of CPU across servers
+// @Tags Clusters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Success 200 {object} models.ServerReport "Latest per-server report"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Cluster not found or not running"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /clusters/{id}/servers [get]
+func (h *ClusterHandler) GetServers(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, _, ok := ownedCluster(ctx, c, h.clusterRepo, id); !ok {
+		return
+	}
+
+	if h.clusterManager == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+	report, err := h.clusterManager.ClusterServers(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cluster is not running"})
+		return
+	}
+
+	// Nothing has been collected yet
+	if report == nil {
+		report = &models.ServerReport{ClusterID: id, Servers: []models.ServerAnalysis{}}
+	}
+
+	c.JSON(http.StatusOK, report)
+}
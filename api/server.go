@@ -126,12 +126,21 @@ func (s *Server) setupRoutes() {
 	clusterRepo := queries.NewClusterRepository(s.db.DB)
 	metricsRepo := queries.NewMetricsRepository(s.db.DB)
 	eventsRepo := queries.NewScalingEventRepository(s.db.DB)
+	predictionsRepo := queries.NewPredictionRepository(s.db.DB)
+	schedulesRepo := queries.NewScheduleRepository(s.db.DB)
+	decisionsRepo := queries.NewDecisionRepository(s.db.DB)
+	freezesRepo := queries.NewFreezeRepository(s.db.DB)
+	scalingRequestsRepo := queries.NewScalingRequestRepository(s.db.DB)
+	pinsRepo := queries.NewPinRepository(s.db.DB)
 
 	// Handlers
 	healthHandler := handlers.NewHealthHandler(s.db)
 	authHandler := handlers.NewAuthHandler(userRepo, s.authService, &s.config)
-	clusterHandler := handlers.NewClusterHandler(clusterRepo, s.clusterManager)
-	metricsHandler := handlers.NewMetricsHandler(metricsRepo, eventsRepo, clusterRepo, &s.config)
+	clusterHandler := handlers.NewClusterHandler(clusterRepo, freezesRepo, pinsRepo, s.clusterManager)
+	metricsHandler := handlers.NewMetricsHandler(metricsRepo, eventsRepo, predictionsRepo, decisionsRepo, clusterRepo, &s.config)
+	scheduleHandler := handlers.NewScheduleHandler(schedulesRepo, clusterRepo)
+	freezeHandler := handlers.NewFreezeHandler(freezesRepo, clusterRepo)
+	scalingRequestHandler := handlers.NewScalingRequestHandler(scalingRequestsRepo, clusterRepo, s.clusterManager)
 
 	// Swagger documentation
 	s.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -163,16 +172,49 @@ func (s *Server) setupRoutes() {
 		protected.PUT("/clusters/:id", clusterHandler.Update)
 		protected.DELETE("/clusters/:id", clusterHandler.Delete)
 		protected.GET("/clusters/:id/status", clusterHandler.GetStatus)
+		protected.GET("/clusters/:id/decisions", clusterHandler.GetDecisions)
+		protected.GET("/clusters/:id/servers", clusterHandler.GetServers)
+		protected.POST("/clusters/:id/scale", clusterHandler.Scale)
+		protected.POST("/clusters/:id/pin", clusterHandler.Pin)
+		protected.DELETE("/clusters/:id/pin", clusterHandler.Unpin)
+		protected.GET("/clusters/:id/decisions/history", metricsHandler.GetDecisionHistory)
+		protected.GET("/clusters/:id/decisions/stats", metricsHandler.GetDecisionStats)
+		protected.GET("/clusters/:id/observe/report", metricsHandler.GetObserveReport)
 
 		// Metrics
 		protected.GET("/clusters/:id/metrics", metricsHandler.GetMetrics)
 		protected.GET("/clusters/:id/metrics/latest", metricsHandler.GetLatestMetrics)
 		protected.GET("/clusters/:id/metrics/hourly", metricsHandler.GetHourlyMetrics)
+		protected.GET("/clusters/:id/metrics/custom", metricsHandler.GetCustomMetrics)
 
 		// Scaling Events
 		protected.GET("/clusters/:id/events", metricsHandler.GetScalingEvents)
 		protected.GET("/clusters/:id/events/stats", metricsHandler.GetScalingStats)
 		protected.GET("/events/recent", metricsHandler.GetRecentEvents)
+
+		// Predictions
+		protected.GET("/clusters/:id/predictions", metricsHandler.GetPredictions)
+		protected.GET("/clusters/:id/predictions/accuracy", metricsHandler.GetPredictionAccuracy)
+
+		// Scaling Schedules
+		protected.GET("/clusters/:id/schedules", scheduleHandler.List)
+		protected.POST("/clusters/:id/schedules", scheduleHandler.Create)
+		protected.GET("/clusters/:id/schedules/:schedule_id", scheduleHandler.Get)
+		protected.PUT("/clusters/:id/schedules/:schedule_id", scheduleHandler.Update)
+		protected.DELETE("/clusters/:id/schedules/:schedule_id", scheduleHandler.Delete)
+
+		// Freeze Windows
+		protected.GET("/freezes", freezeHandler.List)
+		protected.POST("/freezes", freezeHandler.Create)
+		protected.GET("/freezes/:freeze_id", freezeHandler.Get)
+		protected.PUT("/freezes/:freeze_id", freezeHandler.Update)
+		protected.DELETE("/freezes/:freeze_id", freezeHandler.Delete)
+
+		// Scaling Requests
+		protected.GET("/scaling-requests", scalingRequestHandler.List)
+		protected.GET("/scaling-requests/:id", scalingRequestHandler.Get)
+		protected.POST("/scaling-requests/:id/approve", scalingRequestHandler.Approve)
+		protected.POST("/scaling-requests/:id/reject", scalingRequestHandler.Reject)
 	}
 }
 
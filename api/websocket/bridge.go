@@ -109,12 +109,21 @@ func mapEventType(eventType models.EventType) string {
 		return "scaling_event"
 	case models.EventTypeScalingFailed:
 		return "scaling_failed"
-	case models.EventTypeAlert:
+	case models.EventTypeWouldScale:
+		return "would_scale"
+	case models.EventTypeAlert, models.EventTypeServerHot, models.EventTypeServerStale, models.EventTypeLoadImbalance,
+		models.EventTypeDataQuality:
 		return "alert"
 	case models.EventTypeServerAdded, models.EventTypeServerRemoved, models.EventTypeServerActivated:
 		return "server_update"
 	case models.EventTypeDecisionMade:
 		return "decision"
+	case models.EventTypePredictionMade:
+		return "prediction"
+	case models.EventTypeScheduleStarted, models.EventTypeScheduleEnded:
+		return "schedule"
+	case models.EventTypeScalingRequestCreated, models.EventTypeScalingRequestResolved:
+		return "scaling_request"
 	case models.EventTypeError:
 		return "error"
 	default:
@@ -175,10 +175,9 @@ func startClusterPipelines(cfg *config.Config, db *database.DB, orch *orchestrat
 			continue
 		}
 
-		// Create HTTP collector pointing to simulator
-		simulatorURL := fmt.Sprintf("http://localhost:9000/metrics/%s", cluster.ID)
+		// Create HTTP collector for the cluster's endpoint, the simulator by default
 		coll := collector.NewHTTPCollector(collector.HTTPCollectorConfig{
-			Endpoint:  simulatorURL,
+			Endpoint:  cluster.MetricsEndpoint(cfg.Collector.Endpoint),
 			Timeout: 5 * time.Second,
 		})
 
@@ -12,22 +12,39 @@ type Config struct {
 	CPUHighThreshold    float64
 	CPULowThreshold     float64
 	MemoryHighThreshold float64
+	MemoryLowThreshold  float64
 	TrendWindow         time.Duration
 	SpikeThreshold      float64
 	MaxHistoryLength    int
+	Anomaly             AnomalyConfig
+	Servers             ServerConfig
+
+	// CPUStatistic picks the CPU figure thresholds act on; empty means the average.
+	// With a PercentileWindow, max and percentiles pool every server reading from
+	// the snapshots within the window instead of the latest cycle alone.
+	CPUStatistic     models.CPUStatistic
+	PercentileWindow time.Duration
 }
 
 type Analyzer struct {
 	config        Config
-	history       map[string][]metricsSnapshot
+	history       map[string][]Snapshot
 	historyMu     sync.RWMutex
 	maxHistoryLen int
+	anomaly       AnomalyDetector
 }
 
-type metricsSnapshot struct {
-	timestamp time.Time
-	avgCPU    float64
-	avgMemory float64
+// Snapshot is a point-in-time record of a cluster's aggregated usage. ServerCPU
+// holds each server's reading and is empty for snapshots restored from storage.
+type Snapshot struct {
+	Timestamp time.Time
+	AvgCPU    float64
+	AvgMemory float64
+	ServerCPU []float64
+
+	// ScalingCPU holds the configured CPU statistic for snapshots rebuilt from
+	// stored metrics, so sustained runs restore against the figure they track
+	ScalingCPU float64
 }
 
 func New(cfg Config) *Analyzer {
@@ -40,6 +57,9 @@ func New(cfg Config) *Analyzer {
 	if cfg.MemoryHighThreshold == 0 {
 		cfg.MemoryHighThreshold = 85.0
 	}
+	if cfg.MemoryLowThreshold == 0 {
+		cfg.MemoryLowThreshold = 40.0
+	}
 	if cfg.TrendWindow == 0 {
 		cfg.TrendWindow = 5 * time.Minute
 	}
@@ -52,14 +72,48 @@ func New(cfg Config) *Analyzer {
 		maxHistoryLen = 30
 	}
 
+	if cfg.Anomaly.BaselineSamples == 0 {
+		cfg.Anomaly.BaselineSamples = 20
+	}
+	if cfg.Anomaly.MinSamples == 0 {
+		cfg.Anomaly.MinSamples = 10
+	}
+	if cfg.Anomaly.Sensitivity == 0 {
+		cfg.Anomaly.Sensitivity = 3.0
+	}
+	if cfg.Anomaly.MinDeviation == 0 {
+		cfg.Anomaly.MinDeviation = 10.0
+	}
+
+	var detector AnomalyDetector
+	if cfg.Anomaly.Detector != "" {
+		var err error
+		detector, err = NewAnomalyDetector(cfg.Anomaly)
+		if err != nil {
+			logger.Warnf("Anomaly detection disabled: %v", err)
+		}
+	}
+
 	return &Analyzer{
 		config:        cfg,
-		history:       make(map[string][]metricsSnapshot),
+		history:       make(map[string][]Snapshot),
 		maxHistoryLen: maxHistoryLen,
+		anomaly:       detector,
 	}
 }
 
 func (a *Analyzer) Analyze(metrics *models.ClusterMetrics) *models.AnalyzedMetrics {
+	return a.analyze(metrics, true)
+}
+
+// Evaluate analyzes metrics against the cluster's history without adding them to
+// it, so metrics that failed their data-quality checks don't shape later trends,
+// spikes or anomaly baselines
+func (a *Analyzer) Evaluate(metrics *models.ClusterMetrics) *models.AnalyzedMetrics {
+	return a.analyze(metrics, false)
+}
+
+func (a *Analyzer) analyze(metrics *models.ClusterMetrics, record bool) *models.AnalyzedMetrics {
 	if len(metrics.Servers) == 0 {
 		return &models.AnalyzedMetrics{
 			ClusterID:    metrics.ClusterID,
@@ -72,53 +126,114 @@ func (a *Analyzer) Analyze(metrics *models.ClusterMetrics) *models.AnalyzedMetri
 
 	aggregated := metrics.CalculateAggregates()
 
-	a.recordSnapshot(metrics.ClusterID, aggregated.AvgCPU, aggregated.AvgMemory, metrics.Timestamp)
+	serverCPU := make([]float64, len(metrics.Servers))
+	for i, s := range metrics.Servers {
+		serverCPU[i] = s.CPUUsage
+	}
+	history := a.withSnapshot(metrics.ClusterID, Snapshot{
+		Timestamp: metrics.Timestamp,
+		AvgCPU:    aggregated.AvgCPU,
+		AvgMemory: aggregated.AvgMemory,
+		ServerCPU: serverCPU,
+	}, record)
+
+	maxCPU, percentiles := a.cpuSpread(history, aggregated, metrics.Timestamp)
+	scalingCPU := a.config.CPUStatistic.Of(aggregated.AvgCPU, maxCPU, percentiles)
 
-	cpuStatus := a.evaluateCPUThreshold(aggregated.AvgCPU)
+	cpuStatus := a.evaluateCPUThreshold(scalingCPU)
 	memoryStatus := a.evaluateMemoryThreshold(aggregated.AvgMemory)
-	trend := a.calculateTrend(metrics.ClusterID)
-	hasSpike, spikePercent := a.detectSpike(metrics.ClusterID, aggregated.AvgCPU)
+	trend := a.calculateTrend(history)
+	hasSpike, spikePercent := a.detectSpike(history, aggregated.AvgCPU)
+	isAnomaly, anomaly := a.detectAnomaly(history, aggregated.AvgCPU)
+	if anomaly != nil {
+		// Once the detector has a baseline it replaces the relative spike check
+		hasSpike = false
+	}
 
 	analyzed := &models.AnalyzedMetrics{
 		ClusterID:      metrics.ClusterID,
 		Timestamp:      metrics.Timestamp,
 		AvgCPU:         aggregated.AvgCPU,
 		AvgMemory:      aggregated.AvgMemory,
+		AvgLoad:        aggregated.AvgLoad,
+		TotalLoad:      aggregated.AvgLoad * float64(aggregated.ServerCount),
 		ServerCount:    aggregated.ServerCount,
 		CPUStatus:      cpuStatus,
 		MemoryStatus:   memoryStatus,
 		Trend:          trend,
 		HasSpike:       hasSpike,
 		SpikePercent:   spikePercent,
-		Recommendation: a.generateRecommendation(cpuStatus, trend, hasSpike),
+		Recommendation: a.generateRecommendation(cpuStatus, trend, hasSpike, isAnomaly),
+		MaxCPU:         maxCPU,
+		CPUPercentiles: percentiles,
+		CPUStatistic:   a.config.CPUStatistic,
+		ScalingCPU:     scalingCPU,
+		Custom:         aggregated.Custom,
+	}
+	if anomaly != nil {
+		analyzed.IsAnomaly = isAnomaly
+		analyzed.AnomalyScore = anomaly.Score
+		analyzed.AnomalyBaseline = anomaly.Baseline
+		analyzed.AnomalyDetector = a.anomaly.Name()
 	}
 
 	logger.WithCluster(metrics.ClusterID).Debugf(
-		"Analyzed:  cpu=%.1f%% (%s), trend=%s, spike=%v",
-		aggregated.AvgCPU, cpuStatus, trend, hasSpike,
+		"Analyzed:  cpu=%.1f%% (%s), signal=%.1f%%, trend=%s, spike=%v, anomaly=%v",
+		aggregated.AvgCPU, cpuStatus, scalingCPU, trend, hasSpike, isAnomaly,
 	)
 
 	return analyzed
 }
 
-func (a *Analyzer) recordSnapshot(clusterID string, avgCPU, avgMemory float64, timestamp time.Time) {
+// withSnapshot returns a copy of the cluster's history ending in snapshot, storing
+// the snapshot in the history when record is set
+func (a *Analyzer) withSnapshot(clusterID string, snapshot Snapshot, record bool) []Snapshot {
 	a.historyMu.Lock()
 	defer a.historyMu.Unlock()
 
-	snapshot := metricsSnapshot{
-		timestamp: timestamp,
-		avgCPU:    avgCPU,
-		avgMemory: avgMemory,
-	}
-
-	history := a.history[clusterID]
+	stored := a.history[clusterID]
+	history := make([]Snapshot, len(stored), len(stored)+1)
+	copy(history, stored)
 	history = append(history, snapshot)
 
 	if len(history) > a.maxHistoryLen {
 		history = history[len(history)-a.maxHistoryLen:]
 	}
 
-	a.history[clusterID] = history
+	// Stored histories are never appended to in place, so the caller can read
+	// this one without holding the lock
+	if record {
+		a.history[clusterID] = history
+	}
+	return history
+}
+
+// cpuSpread returns the maximum and percentiles of CPU across servers, pooled over
+// the percentile window when one is configured. The window can't reach further
+// back than the analyzer's history.
+func (a *Analyzer) cpuSpread(history []Snapshot, aggregated models.AggregatedMetrics, now time.Time) (float64, models.CPUPercentiles) {
+	if a.config.PercentileWindow <= 0 {
+		return aggregated.MaxCPU, aggregated.CPUPercentiles
+	}
+
+	cutoff := now.Add(-a.config.PercentileWindow)
+	var readings []float64
+	for _, s := range history {
+		if s.Timestamp.After(cutoff) {
+			readings = append(readings, s.ServerCPU...)
+		}
+	}
+	if len(readings) == 0 {
+		return aggregated.MaxCPU, aggregated.CPUPercentiles
+	}
+
+	maxCPU := readings[0]
+	for _, cpu := range readings[1:] {
+		if cpu > maxCPU {
+			maxCPU = cpu
+		}
+	}
+	return maxCPU, models.NewCPUPercentiles(readings)
 }
 
 func (a *Analyzer) evaluateCPUThreshold(cpu float64) models.ThresholdStatus {
@@ -143,19 +258,15 @@ func (a *Analyzer) evaluateMemoryThreshold(memory float64) models.ThresholdStatu
 	}
 }
 
-func (a *Analyzer) calculateTrend(clusterID string) models.Trend {
-	a.historyMu.RLock()
-	defer a.historyMu.RUnlock()
-
-	history := a.history[clusterID]
+func (a *Analyzer) calculateTrend(history []Snapshot) models.Trend {
 	if len(history) < 3 {
 		return models.TrendStable
 	}
 
 	cutoff := time.Now().Add(-a.config.TrendWindow)
-	var recentSnapshots []metricsSnapshot
+	var recentSnapshots []Snapshot
 	for _, s := range history {
-		if s.timestamp.After(cutoff) {
+		if s.Timestamp.After(cutoff) {
 			recentSnapshots = append(recentSnapshots, s)
 		}
 	}
@@ -188,22 +299,18 @@ func (a *Analyzer) calculateTrend(clusterID string) models.Trend {
 	}
 }
 
-func (a *Analyzer) averageCPU(snapshots []metricsSnapshot) float64 {
+func (a *Analyzer) averageCPU(snapshots []Snapshot) float64 {
 	if len(snapshots) == 0 {
 		return 0
 	}
 	var total float64
 	for _, s := range snapshots {
-		total += s.avgCPU
+		total += s.AvgCPU
 	}
 	return total / float64(len(snapshots))
 }
 
-func (a *Analyzer) detectSpike(clusterID string, currentCPU float64) (bool, float64) {
-	a.historyMu.RLock()
-	defer a.historyMu.RUnlock()
-
-	history := a.history[clusterID]
+func (a *Analyzer) detectSpike(history []Snapshot, currentCPU float64) (bool, float64) {
 	if len(history) < 2 {
 		return false, 0
 	}
@@ -213,8 +320,8 @@ func (a *Analyzer) detectSpike(clusterID string, currentCPU float64) (bool, floa
 	found := false
 
 	for i := len(history) - 2; i >= 0; i-- {
-		if history[i].timestamp.Before(oneMinuteAgo) {
-			previousCPU = history[i].avgCPU
+		if history[i].Timestamp.Before(oneMinuteAgo) {
+			previousCPU = history[i].AvgCPU
 			found = true
 			break
 		}
@@ -222,7 +329,7 @@ func (a *Analyzer) detectSpike(clusterID string, currentCPU float64) (bool, floa
 
 	if !found {
 		if len(history) >= 2 {
-			previousCPU = history[len(history)-2].avgCPU
+			previousCPU = history[len(history)-2].AvgCPU
 		} else {
 			return false, 0
 		}
@@ -234,6 +341,12 @@ func (a *Analyzer) detectSpike(clusterID string, currentCPU float64) (bool, floa
 
 	changePercent := ((currentCPU - previousCPU) / previousCPU) * 100
 
+	// With a detector configured, a spike has to clear its MinDeviation too, so a
+	// jump from a low base isn't one while the baseline is still building up
+	if a.anomaly != nil && currentCPU-previousCPU < a.config.Anomaly.MinDeviation {
+		return false, changePercent
+	}
+
 	if changePercent >= a.config.SpikeThreshold {
 		return true, changePercent
 	}
@@ -241,12 +354,45 @@ func (a *Analyzer) detectSpike(clusterID string, currentCPU float64) (bool, floa
 	return false, changePercent
 }
 
-func (a *Analyzer) generateRecommendation(cpuStatus models.ThresholdStatus, trend models.Trend, hasSpike bool) string {
+// detectAnomaly scores currentCPU against the cluster's rolling baseline. Only rises
+// that clear both the detector's sensitivity and MinDeviation count, so a jump from
+// a low, quiet baseline isn't flagged. The returned anomaly is nil until the
+// baseline has MinSamples snapshots or when no detector is configured.
+func (a *Analyzer) detectAnomaly(history []Snapshot, currentCPU float64) (bool, *Anomaly) {
+	if a.anomaly == nil {
+		return false, nil
+	}
+
+	// The latest snapshot is the sample being scored
+	if len(history) > 0 {
+		history = history[:len(history)-1]
+	}
+	if len(history) > a.config.Anomaly.BaselineSamples {
+		history = history[len(history)-a.config.Anomaly.BaselineSamples:]
+	}
+	if len(history) < a.config.Anomaly.MinSamples {
+		return false, nil
+	}
+
+	baseline := make([]float64, len(history))
+	for i, s := range history {
+		baseline[i] = s.AvgCPU
+	}
+
+	anomaly := a.anomaly.Score(baseline, currentCPU)
+	isAnomaly := anomaly.Score >= a.config.Anomaly.Sensitivity &&
+		currentCPU-anomaly.Baseline >= a.config.Anomaly.MinDeviation
+	return isAnomaly, &anomaly
+}
+
+func (a *Analyzer) generateRecommendation(cpuStatus models.ThresholdStatus, trend models.Trend, hasSpike, isAnomaly bool) string {
 	switch {
 	case cpuStatus == models.ThresholdCritical: 
 		return "immediate_scale_up"
 	case hasSpike:
 		return "scale_up_spike_detected"
+	case isAnomaly:
+		return "scale_up_anomaly_detected"
 	case cpuStatus == models.ThresholdWarning && trend == models.TrendRising:
 		return "scale_up_rising_trend"
 	case cpuStatus == models.ThresholdWarning: 
@@ -258,12 +404,30 @@ func (a *Analyzer) generateRecommendation(cpuStatus models.ThresholdStatus, tren
 	}
 }
 
-func (a *Analyzer) GetHistory(clusterID string) []metricsSnapshot {
+// Restore seeds a cluster's history with stored snapshots, oldest first, so trends
+// don't restart from nothing. A cluster that already has live history is left alone.
+func (a *Analyzer) Restore(clusterID string, snapshots []Snapshot) {
+	a.historyMu.Lock()
+	defer a.historyMu.Unlock()
+
+	if len(a.history[clusterID]) > 0 || len(snapshots) == 0 {
+		return
+	}
+
+	if len(snapshots) > a.maxHistoryLen {
+		snapshots = snapshots[len(snapshots)-a.maxHistoryLen:]
+	}
+	history := make([]Snapshot, len(snapshots))
+	copy(history, snapshots)
+	a.history[clusterID] = history
+}
+
+func (a *Analyzer) GetHistory(clusterID string) []Snapshot {
 	a.historyMu.RLock()
 	defer a.historyMu.RUnlock()
 
 	history := a.history[clusterID]
-	result := make([]metricsSnapshot, len(history))
+	result := make([]Snapshot, len(history))
 	copy(result, history)
 	return result
 }
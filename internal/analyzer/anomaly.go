@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	DetectorZScore = "zscore"
+	DetectorEWMA   = "ewma"
+	DetectorMAD    = "mad"
+)
+
+var ErrUnknownDetector = errors.New("unknown anomaly detector")
+
+// minSpread floors a baseline's spread so a nearly flat baseline doesn't turn
+// sampling noise into huge scores
+const minSpread = 0.5
+
+// madScale makes the median absolute deviation comparable to a standard deviation
+const madScale = 1.4826
+
+type AnomalyConfig struct {
+	Detector        string  // empty disables anomaly detection
+	BaselineSamples int     // most recent snapshots the baseline is built from
+	MinSamples      int     // baseline size needed before anything is flagged
+	Sensitivity     float64 // score, in baseline deviations, that counts as anomalous
+	MinDeviation    float64 // CPU points above the baseline an anomaly must also reach
+	EWMAAlpha       float64 // smoothing factor for the ewma detector
+}
+
+// Anomaly is a CPU sample scored against a cluster's rolling baseline
+type Anomaly struct {
+	Score    float64 // signed distance from Baseline in units of the baseline's spread
+	Baseline float64 // centre of the baseline the score is measured from
+}
+
+// AnomalyDetector scores a CPU sample against a cluster's rolling baseline
+type AnomalyDetector interface {
+	// Name returns the detector identifier reported on analyzed metrics
+	Name() string
+
+	// Score measures value against baseline, oldest sample first
+	Score(baseline []float64, value float64) Anomaly
+}
+
+func NewAnomalyDetector(cfg AnomalyConfig) (AnomalyDetector, error) {
+	switch cfg.Detector {
+	case DetectorZScore:
+		return zScoreDetector{}, nil
+	case DetectorEWMA:
+		alpha := cfg.EWMAAlpha
+		if alpha <= 0 || alpha > 1 {
+			alpha = 0.3
+		}
+		return ewmaDetector{alpha: alpha}, nil
+	case DetectorMAD:
+		return madDetector{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDetector, cfg.Detector)
+	}
+}
+
+// zScoreDetector measures the sample in standard deviations from the baseline mean
+type zScoreDetector struct{}
+
+func (zScoreDetector) Name() string { return DetectorZScore }
+
+func (zScoreDetector) Score(baseline []float64, value float64) Anomaly {
+	mean, stdDev := meanStdDev(baseline)
+	return Anomaly{
+		Score:    (value - mean) / math.Max(stdDev, minSpread),
+		Baseline: mean,
+	}
+}
+
+// ewmaDetector runs an exponentially weighted moving average through the baseline
+// and the sample, and scores it against the EWMA control limits. Small shifts add
+// up in the average, so it catches slow surges a single-sample test misses.
+type ewmaDetector struct {
+	alpha float64
+}
+
+func (ewmaDetector) Name() string { return DetectorEWMA }
+
+func (d ewmaDetector) Score(baseline []float64, value float64) Anomaly {
+	mean, stdDev := meanStdDev(baseline)
+
+	smoothed := mean
+	for _, v := range baseline {
+		smoothed = d.alpha*v + (1-d.alpha)*smoothed
+	}
+	smoothed = d.alpha*value + (1-d.alpha)*smoothed
+
+	// Steady-state standard deviation of the EWMA statistic
+	limit := math.Max(stdDev, minSpread) * math.Sqrt(d.alpha/(2-d.alpha))
+	return Anomaly{
+		Score:    (smoothed - mean) / limit,
+		Baseline: mean,
+	}
+}
+
+// madDetector measures the sample in scaled median absolute deviations from the
+// baseline median, so a few outliers in the baseline don't widen it
+type madDetector struct{}
+
+func (madDetector) Name() string { return DetectorMAD }
+
+func (madDetector) Score(baseline []float64, value float64) Anomaly {
+	centre := median(baseline)
+
+	deviations := make([]float64, len(baseline))
+	for i, v := range baseline {
+		deviations[i] = math.Abs(v - centre)
+	}
+	mad := median(deviations) * madScale
+
+	return Anomaly{
+		Score:    (value - centre) / math.Max(mad, minSpread),
+		Baseline: centre,
+	}
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
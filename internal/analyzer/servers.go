@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"maps"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+type ServerConfig struct {
+	HotCPUThreshold    float64       // CPU a server must reach to count as hot
+	HotCPUMargin       float64       // CPU points a hot server must sit above the cluster average
+	ImbalanceThreshold float64       // coefficient of variation of CPU that counts as imbalanced
+	ImbalanceMinCPU    float64       // busiest server's CPU below which imbalance is ignored
+	StaleAfter         time.Duration // how long repeated or all-zero metrics last before a server is stale
+}
+
+type serverHistory struct {
+	last           models.ServerMetric
+	seen           time.Time
+	unchangedSince time.Time
+}
+
+// ServerAnalyzer looks at each server on its own, finding hot servers, uneven load
+// and servers whose metrics stopped moving, all of which cluster averages hide
+type ServerAnalyzer struct {
+	config   ServerConfig
+	clusters map[string]map[string]*serverHistory
+	mu       sync.Mutex
+}
+
+func NewServerAnalyzer(cfg ServerConfig) *ServerAnalyzer {
+	if cfg.HotCPUThreshold == 0 {
+		cfg.HotCPUThreshold = 90.0
+	}
+	if cfg.HotCPUMargin == 0 {
+		cfg.HotCPUMargin = 25.0
+	}
+	if cfg.ImbalanceThreshold == 0 {
+		cfg.ImbalanceThreshold = 0.5
+	}
+	if cfg.ImbalanceMinCPU == 0 {
+		cfg.ImbalanceMinCPU = 50.0
+	}
+	if cfg.StaleAfter == 0 {
+		cfg.StaleAfter = 2 * time.Minute
+	}
+
+	return &ServerAnalyzer{
+		config:   cfg,
+		clusters: make(map[string]map[string]*serverHistory),
+	}
+}
+
+func (a *ServerAnalyzer) Analyze(metrics *models.ClusterMetrics) *models.ServerReport {
+	report := &models.ServerReport{
+		ClusterID: metrics.ClusterID,
+		Timestamp: metrics.Timestamp,
+		Servers:   make([]models.ServerAnalysis, 0, len(metrics.Servers)),
+	}
+
+	a.mu.Lock()
+	histories := a.updateHistory(metrics)
+	a.mu.Unlock()
+
+	// Stale servers are left out of the spread so their frozen readings don't skew it
+	var fresh []float64
+	for _, s := range metrics.Servers {
+		server := models.ServerAnalysis{
+			ServerID:    s.ServerID,
+			CPUUsage:    s.CPUUsage,
+			MemoryUsage: s.MemoryUsage,
+			RequestLoad: s.RequestLoad,
+			Status:      models.ServerStatusNormal,
+			LastSeen:    metrics.Timestamp,
+		}
+		if h := histories[s.ServerID]; !h.unchangedSince.IsZero() {
+			since := h.unchangedSince
+			server.UnchangedSince = &since
+			if metrics.Timestamp.Sub(since) >= a.config.StaleAfter {
+				server.Status = models.ServerStatusStale
+			}
+		}
+		if server.Status != models.ServerStatusStale {
+			fresh = append(fresh, s.CPUUsage)
+		}
+		report.Servers = append(report.Servers, server)
+	}
+	sort.Slice(report.Servers, func(i, j int) bool {
+		return report.Servers[i].ServerID < report.Servers[j].ServerID
+	})
+
+	if len(fresh) == 0 {
+		return report
+	}
+
+	mean, stdDev := meanStdDev(fresh)
+	report.AvgCPU = mean
+	report.CPUStdDev = stdDev
+	report.MinCPU, report.MaxCPU = fresh[0], fresh[0]
+	for _, cpu := range fresh[1:] {
+		report.MinCPU = math.Min(report.MinCPU, cpu)
+		report.MaxCPU = math.Max(report.MaxCPU, cpu)
+	}
+	if mean > 0 {
+		report.CPUVariation = stdDev / mean
+	}
+	report.Imbalanced = len(fresh) >= 2 &&
+		report.CPUVariation >= a.config.ImbalanceThreshold &&
+		report.MaxCPU >= a.config.ImbalanceMinCPU
+
+	for i := range report.Servers {
+		server := &report.Servers[i]
+		if server.Status == models.ServerStatusStale {
+			continue
+		}
+		server.CPUDeviation = server.CPUUsage - mean
+		if server.CPUUsage >= a.config.HotCPUThreshold && server.CPUDeviation >= a.config.HotCPUMargin {
+			server.Status = models.ServerStatusHot
+		}
+	}
+
+	return report
+}
+
+// updateHistory records the cluster's latest server metrics and forgets servers
+// that stopped reporting. Callers must hold mu.
+func (a *ServerAnalyzer) updateHistory(metrics *models.ClusterMetrics) map[string]serverHistory {
+	previous := a.clusters[metrics.ClusterID]
+	current := make(map[string]*serverHistory, len(metrics.Servers))
+	snapshot := make(map[string]serverHistory, len(metrics.Servers))
+
+	for _, s := range metrics.Servers {
+		h := &serverHistory{last: s, seen: metrics.Timestamp}
+		prev, exists := previous[s.ServerID]
+		switch {
+		case exists && sameReading(prev.last, s) && !prev.unchangedSince.IsZero():
+			h.unchangedSince = prev.unchangedSince
+		case exists && sameReading(prev.last, s):
+			h.unchangedSince = prev.seen
+		case s.CPUUsage == 0 && s.MemoryUsage == 0 && s.RequestLoad == 0:
+			h.unchangedSince = metrics.Timestamp
+		}
+		current[s.ServerID] = h
+		snapshot[s.ServerID] = *h
+	}
+
+	a.clusters[metrics.ClusterID] = current
+	return snapshot
+}
+
+// sameReading reports whether a server sent exactly the same metrics twice
+func sameReading(a, b models.ServerMetric) bool {
+	return a.ServerID == b.ServerID && a.CPUUsage == b.CPUUsage && a.MemoryUsage == b.MemoryUsage &&
+		a.RequestLoad == b.RequestLoad && maps.Equal(a.Custom, b.Custom)
+}
+
+func (a *ServerAnalyzer) Reset(clusterID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.clusters, clusterID)
+}
@@ -8,15 +8,19 @@ import (
 )
 
 type SustainedTracker struct {
-	highStartTimes map[string]time.Time
-	lowStartTimes  map[string]time.Time
-	mu             sync.RWMutex
+	highStartTimes       map[string]time.Time
+	lowStartTimes        map[string]time.Time
+	memoryHighStartTimes map[string]time.Time
+	memoryLowStartTimes  map[string]time.Time
+	mu                   sync.RWMutex
 }
 
 func NewSustainedTracker() *SustainedTracker {
 	return &SustainedTracker{
-		highStartTimes: make(map[string]time.Time),
-		lowStartTimes:  make(map[string]time.Time),
+		highStartTimes:       make(map[string]time.Time),
+		lowStartTimes:        make(map[string]time.Time),
+		memoryHighStartTimes: make(map[string]time.Time),
+		memoryLowStartTimes:  make(map[string]time.Time),
 	}
 }
 
@@ -27,7 +31,7 @@ func (t *SustainedTracker) Update(clusterID string, analyzed *models.AnalyzedMet
 	now := time.Now()
 
 	// Track high CPU: start when above threshold, clear when below
-	if analyzed.AvgCPU >= cfg.CPUHighThreshold {
+	if analyzed.SignalCPU() >= cfg.CPUHighThreshold {
 		if _, exists := t.highStartTimes[clusterID]; !exists {
 			t.highStartTimes[clusterID] = now
 		}
@@ -36,7 +40,7 @@ func (t *SustainedTracker) Update(clusterID string, analyzed *models.AnalyzedMet
 	}
 
 	// Track low CPU: start when below threshold, clear when above
-	if analyzed.AvgCPU <= cfg.CPULowThreshold {
+	if analyzed.SignalCPU() <= cfg.CPULowThreshold {
 		if _, exists := t.lowStartTimes[clusterID]; !exists {
 			t.lowStartTimes[clusterID] = now
 		}
@@ -44,12 +48,80 @@ func (t *SustainedTracker) Update(clusterID string, analyzed *models.AnalyzedMet
 		delete(t.lowStartTimes, clusterID)
 	}
 
+	// Track high memory: only when a threshold is configured
+	if cfg.MemoryHighThreshold > 0 && analyzed.AvgMemory >= cfg.MemoryHighThreshold {
+		if _, exists := t.memoryHighStartTimes[clusterID]; !exists {
+			t.memoryHighStartTimes[clusterID] = now
+		}
+	} else {
+		delete(t.memoryHighStartTimes, clusterID)
+	}
+
+	// Track low memory: start when below threshold, clear when above
+	if analyzed.AvgMemory <= cfg.MemoryLowThreshold {
+		if _, exists := t.memoryLowStartTimes[clusterID]; !exists {
+			t.memoryLowStartTimes[clusterID] = now
+		}
+	} else {
+		delete(t.memoryLowStartTimes, clusterID)
+	}
+
 	if startTime, exists := t.highStartTimes[clusterID]; exists {
 		analyzed.SustainedHighAt = &startTime
 	}
 	if startTime, exists := t.lowStartTimes[clusterID]; exists {
 		analyzed.SustainedLowAt = &startTime
 	}
+	if startTime, exists := t.memoryHighStartTimes[clusterID]; exists {
+		analyzed.SustainedMemoryHighAt = &startTime
+	}
+	if startTime, exists := t.memoryLowStartTimes[clusterID]; exists {
+		analyzed.SustainedMemoryLowAt = &startTime
+	}
+}
+
+// Restore rebuilds a cluster's sustained start times from stored snapshots, oldest
+// first. Each start is the first snapshot of the unbroken run that reaches the latest
+// one; snapshots more than maxGap apart break a run.
+func (t *SustainedTracker) Restore(clusterID string, snapshots []Snapshot, cfg Config, maxGap time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	restore := func(starts map[string]time.Time, matches func(Snapshot) bool) {
+		start, ok := runStart(snapshots, matches, maxGap)
+		if !ok {
+			return
+		}
+		if existing, exists := starts[clusterID]; !exists || start.Before(existing) {
+			starts[clusterID] = start
+		}
+	}
+
+	restore(t.highStartTimes, func(s Snapshot) bool { return s.ScalingCPU >= cfg.CPUHighThreshold })
+	restore(t.lowStartTimes, func(s Snapshot) bool { return s.ScalingCPU <= cfg.CPULowThreshold })
+	if cfg.MemoryHighThreshold > 0 {
+		restore(t.memoryHighStartTimes, func(s Snapshot) bool { return s.AvgMemory >= cfg.MemoryHighThreshold })
+	}
+	restore(t.memoryLowStartTimes, func(s Snapshot) bool { return s.AvgMemory <= cfg.MemoryLowThreshold })
+}
+
+// runStart walks back from the latest snapshot while matches holds and returns
+// the timestamp the run started at
+func runStart(snapshots []Snapshot, matches func(Snapshot) bool, maxGap time.Duration) (time.Time, bool) {
+	start := -1
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !matches(snapshots[i]) {
+			break
+		}
+		if start >= 0 && snapshots[start].Timestamp.Sub(snapshots[i].Timestamp) > maxGap {
+			break
+		}
+		start = i
+	}
+	if start < 0 {
+		return time.Time{}, false
+	}
+	return snapshots[start].Timestamp, true
 }
 
 func (t *SustainedTracker) GetHighDuration(clusterID string) time.Duration {
@@ -72,10 +144,32 @@ func (t *SustainedTracker) GetLowDuration(clusterID string) time.Duration {
 	return 0
 }
 
+func (t *SustainedTracker) GetMemoryHighDuration(clusterID string) time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if startTime, exists := t.memoryHighStartTimes[clusterID]; exists {
+		return time.Since(startTime)
+	}
+	return 0
+}
+
+func (t *SustainedTracker) GetMemoryLowDuration(clusterID string) time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if startTime, exists := t.memoryLowStartTimes[clusterID]; exists {
+		return time.Since(startTime)
+	}
+	return 0
+}
+
 func (t *SustainedTracker) Reset(clusterID string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	delete(t.highStartTimes, clusterID)
 	delete(t.lowStartTimes, clusterID)
+	delete(t.memoryHighStartTimes, clusterID)
+	delete(t.memoryLowStartTimes, clusterID)
 }
\ No newline at end of file
@@ -44,6 +44,7 @@ type simulatorResponse struct {
 	ClusterID string                    `json:"cluster_id"`
 	Timestamp string                    `json:"timestamp"`
 	Servers   []simulatorServerResponse `json:"servers"`
+	Custom    map[string]float64        `json:"custom,omitempty"`
 }
 
 type simulatorServerResponse struct {
@@ -51,6 +52,9 @@ type simulatorServerResponse struct {
 	CPUUsage    float64 `json:"cpu_usage"`
 	MemoryUsage float64 `json:"memory_usage"`
 	RequestLoad int     `json:"request_load"`
+
+	// Custom carries any other metrics the server exports, by name
+	Custom map[string]float64 `json:"custom,omitempty"`
 }
 
 func (c *HTTPCollector) Collect(ctx context.Context, clusterID string) (*models.ClusterMetrics, error) {
@@ -108,21 +112,31 @@ func (c *HTTPCollector) convertResponse(clusterID string, resp *simulatorRespons
 			CPUUsage:    s.CPUUsage,
 			MemoryUsage: s.MemoryUsage,
 			RequestLoad: s.RequestLoad,
+			Custom:      validCustom(clusterID, s.Custom),
 		}
 	}
 
-	timestamp := time.Now()
-	if resp.Timestamp != "" {
-		if parsed, err := time.Parse(time.RFC3339, resp.Timestamp); err == nil {
-			timestamp = parsed
-		}
-	}
-
-	return &models.ClusterMetrics{
+	metrics := &models.ClusterMetrics{
 		ClusterID:  clusterID,
-		Timestamp: timestamp,
+		Timestamp: time.Now(),
 		Servers:   servers,
+		Custom:    validCustom(clusterID, resp.Custom),
+	}
+
+	// Fall back to the collection time, flagged so data-quality checks can see it
+	parsed, err := time.Parse(time.RFC3339, resp.Timestamp)
+	switch {
+	case resp.Timestamp == "":
+		metrics.EstimatedTimestamp = true
+		logger.WithCluster(clusterID).Warn("Metrics response has no timestamp, using collection time")
+	case err != nil:
+		metrics.EstimatedTimestamp = true
+		logger.WithCluster(clusterID).Warnf("Metrics response timestamp %q is invalid, using collection time: %v", resp.Timestamp, err)
+	default:
+		metrics.Timestamp = parsed
 	}
+
+	return metrics
 }
 
 func (c *HTTPCollector) HealthCheck(ctx context.Context) error {
@@ -149,4 +163,16 @@ func (c *HTTPCollector) HealthCheck(ctx context.Context) error {
 func (c *HTTPCollector) Close() error {
 	c.client.CloseIdleConnections()
 	return nil
-}
\ No newline at end of file
+}
+
+// validCustom drops custom metrics whose names can't be stored or referenced by
+// a custom signal
+func validCustom(clusterID string, custom map[string]float64) map[string]float64 {
+	for name := range custom {
+		if !models.ValidCustomMetricName(name) {
+			logger.WithCluster(clusterID).Warnf("Dropping custom metric with invalid name %q", name)
+			delete(custom, name)
+		}
+	}
+	return custom
+}
@@ -0,0 +1,113 @@
+package collector
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+type QualityConfig struct {
+	Policy               models.DataQualityPolicy
+	StaleIntervals       int  // collection intervals a metrics timestamp may lag before it's stale
+	CheckServerCount     bool // compare the reporting servers with the scaler's cluster state
+	ServerCountTolerance int  // servers the count may differ by before it's flagged
+}
+
+// QualityChecker validates collected metrics before the pipeline acts on them
+type QualityChecker struct {
+	config QualityConfig
+}
+
+func NewQualityChecker(cfg QualityConfig) *QualityChecker {
+	if !cfg.Policy.Valid() {
+		cfg.Policy = models.DataQualityBlockScaleDown
+	}
+	if cfg.StaleIntervals <= 0 {
+		cfg.StaleIntervals = 3
+	}
+	if cfg.ServerCountTolerance < 0 {
+		cfg.ServerCountTolerance = 0
+	}
+
+	return &QualityChecker{config: cfg}
+}
+
+func (q *QualityChecker) Policy() models.DataQualityPolicy {
+	return q.config.Policy
+}
+
+func (q *QualityChecker) ChecksServerCount() bool {
+	return q.config.CheckServerCount
+}
+
+// MaxAge is how old metrics can be before they're stale
+func (q *QualityChecker) MaxAge(interval time.Duration) time.Duration {
+	return time.Duration(q.config.StaleIntervals) * interval
+}
+
+// Check returns every issue found in metrics. A nil state skips the server count check.
+func (q *QualityChecker) Check(metrics *models.ClusterMetrics, state *models.ClusterState, interval time.Duration, now time.Time) []models.DataQualityIssue {
+	var issues []models.DataQualityIssue
+
+	if metrics.EstimatedTimestamp || metrics.Timestamp.IsZero() {
+		issues = append(issues, models.DataQualityIssue{
+			Type:    models.DataQualityMissingTimestamp,
+			Message: "metrics have no usable timestamp",
+		})
+	} else if age := now.Sub(metrics.Timestamp); age > q.MaxAge(interval) {
+		issues = append(issues, models.DataQualityIssue{
+			Type:    models.DataQualityStaleTimestamp,
+			Message: fmt.Sprintf("metrics are %s old, over %d collection intervals", age.Round(time.Second), q.config.StaleIntervals),
+		})
+	}
+
+	if len(metrics.Servers) == 0 {
+		issues = append(issues, models.DataQualityIssue{
+			Type:    models.DataQualityNoServers,
+			Message: "no servers reported metrics",
+		})
+	} else if q.config.CheckServerCount && state != nil {
+		// Provisioning servers may or may not report yet, so anything from the
+		// active servers up to every tracked server is expected
+		low := state.ActiveServers - q.config.ServerCountTolerance
+		high := state.TotalServers + q.config.ServerCountTolerance
+		if count := len(metrics.Servers); count < low || count > high {
+			issues = append(issues, models.DataQualityIssue{
+				Type: models.DataQualityServerCount,
+				Message: fmt.Sprintf("%d servers reported metrics, scaler tracks %d active of %d",
+					count, state.ActiveServers, state.TotalServers),
+			})
+		}
+	}
+
+	for _, s := range metrics.Servers {
+		if msg := outOfRange(s); msg != "" {
+			issues = append(issues, models.DataQualityIssue{
+				Type:     models.DataQualityOutOfRange,
+				ServerID: s.ServerID,
+				Message:  msg,
+			})
+		}
+	}
+
+	return issues
+}
+
+func outOfRange(s models.ServerMetric) string {
+	switch {
+	case math.IsNaN(s.CPUUsage) || s.CPUUsage < 0 || s.CPUUsage > 100:
+		return fmt.Sprintf("cpu_usage %v is outside 0-100", s.CPUUsage)
+	case math.IsNaN(s.MemoryUsage) || s.MemoryUsage < 0 || s.MemoryUsage > 100:
+		return fmt.Sprintf("memory_usage %v is outside 0-100", s.MemoryUsage)
+	case s.RequestLoad < 0:
+		return fmt.Sprintf("request_load %d is negative", s.RequestLoad)
+	}
+	for name, value := range s.Custom {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Sprintf("custom metric %s is not a number", name)
+		}
+	}
+	return ""
+}
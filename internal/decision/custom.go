@@ -0,0 +1,61 @@
+package decision
+
+import (
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// customSignalValue returns the figure a custom signal acts on, false when no
+// server reported the metric this cycle
+func customSignalValue(analyzed *models.AnalyzedMetrics, signal models.CustomSignal) (float64, bool) {
+	value, ok := analyzed.Custom[signal.Name]
+	if !ok {
+		return 0, false
+	}
+	return value.Of(signal.Aggregation), true
+}
+
+// customIdealServers returns the servers that would bring a custom signal to its
+// target. A sum is shared across servers; an average or maximum falls in proportion
+// as servers are added.
+func customIdealServers(signal models.CustomSignal, value, servers float64) float64 {
+	if signal.Aggregation == models.CustomAggregationSum {
+		return value / signal.Target
+	}
+	return value * servers / signal.Target
+}
+
+// customHigh returns the scale-up reason for the first custom signal at or above
+// its high threshold, or an empty string when none is
+func (e *Engine) customHigh(analyzed *models.AnalyzedMetrics, trace *models.DecisionTrace) string {
+	for _, signal := range e.config.CustomSignals {
+		value, ok := customSignalValue(analyzed, signal)
+		if !ok || signal.High == nil {
+			continue
+		}
+		name := string(signal.Signal()) + "_high"
+		if trace.Check(name, value >= *signal.High, value, *signal.High) {
+			return name
+		}
+	}
+	return ""
+}
+
+// customNotLow reports whether a custom signal is still above its low threshold,
+// which holds off scaling down. A signal with no reading this cycle can't be shown
+// to be low, so it holds off scaling down too.
+func (e *Engine) customNotLow(analyzed *models.AnalyzedMetrics, trace *models.DecisionTrace) bool {
+	for _, signal := range e.config.CustomSignals {
+		if signal.Low == nil {
+			continue
+		}
+		value, ok := customSignalValue(analyzed, signal)
+		if !ok {
+			trace.Check(string(signal.Signal())+"_missing", true, nil, *signal.Low)
+			return true
+		}
+		if trace.Check(string(signal.Signal())+"_not_low", value > *signal.Low, value, *signal.Low) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package decision
 
 import (
+	"math"
 	"sync"
 	"time"
 
@@ -8,6 +9,15 @@ import (
 	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 )
 
+// Scale-down modes
+const (
+	// ScaleDownConservative removes one server per decision
+	ScaleDownConservative = "conservative"
+
+	// ScaleDownProportional sizes the step from utilization, mirroring scale-up
+	ScaleDownProportional = "proportional"
+)
+
 type Config struct {
 	CooldownPeriod          time.Duration
 	ScaleDownCooldownPeriod time.Duration
@@ -15,11 +25,24 @@ type Config struct {
 	MinServers              int
 	MaxServers              int
 	MaxScaleStep            int
+	ScaleDownMode           string
+	MaxScaleDownStep        int
+	MaxScaleDownPercent     float64 // largest share of the cluster removed in one step
+	ScaleDownSafetyMargin   float64 // headroom kept below the high thresholds after removal; zero keeps none
 	TargetCPU               float64
 	CPUHighThreshold        float64
 	CPULowThreshold         float64
+	TargetMemory            float64
+	MemoryHighThreshold     float64
 	SustainedHighDuration   time.Duration
 	SustainedLowDuration    time.Duration
+	PredictionMinConfidence float64
+	ScalingMode             models.ScalingMode
+	ScalingSignals          []models.ScalingSignal
+	TargetLoadPerServer     float64
+	CustomSignals           []models.CustomSignal
+	Policy                  *models.ScalingPolicy
+	Flap                    FlapConfig
 }
 
 type Engine struct {
@@ -27,6 +50,16 @@ type Engine struct {
 	lastScaleUpTimes   map[string]time.Time
 	lastScaleDownTimes map[string]time.Time
 	mu                 sync.RWMutex
+
+	// Overrides replace MinServers/MaxServers in config; limitsMu guards both
+	baseMinServers int
+	baseMaxServers int
+	overrides      map[string]Override
+	activeOverride *Override
+	limitsMu       sync.RWMutex
+
+	// flap is nil unless flap damping is enabled
+	flap *FlapDetector
 }
 
 func NewEngine(cfg Config) *Engine {
@@ -45,6 +78,15 @@ func NewEngine(cfg Config) *Engine {
 	if cfg.MaxScaleStep == 0 {
 		cfg.MaxScaleStep = 3
 	}
+	if cfg.ScaleDownMode == "" {
+		cfg.ScaleDownMode = ScaleDownConservative
+	}
+	if cfg.MaxScaleDownStep == 0 {
+		cfg.MaxScaleDownStep = cfg.MaxScaleStep
+	}
+	if cfg.MaxScaleDownPercent == 0 {
+		cfg.MaxScaleDownPercent = 25.0
+	}
 	if cfg.TargetCPU == 0 {
 		cfg.TargetCPU = 70.0
 	}
@@ -54,6 +96,12 @@ func NewEngine(cfg Config) *Engine {
 	if cfg.CPULowThreshold == 0 {
 		cfg.CPULowThreshold = 30.0
 	}
+	if cfg.TargetMemory == 0 {
+		cfg.TargetMemory = 70.0
+	}
+	if cfg.MemoryHighThreshold == 0 {
+		cfg.MemoryHighThreshold = 85.0
+	}
 	if cfg.SustainedHighDuration == 0 {
 		// cfg.SustainedHighDuration = 2 * time.Minute
 		cfg.SustainedHighDuration = 30 * time.Second
@@ -65,12 +113,28 @@ func NewEngine(cfg Config) *Engine {
 		// cfg.ScaleDownCooldownPeriod = 3 * time.Minute
 		cfg.ScaleDownCooldownPeriod = 30 * time.Second
 	}
+	if cfg.PredictionMinConfidence == 0 {
+		cfg.PredictionMinConfidence = 0.7
+	}
+	if cfg.ScalingMode == "" {
+		cfg.ScalingMode = models.ScalingModeThreshold
+	}
+	if len(cfg.ScalingSignals) == 0 {
+		cfg.ScalingSignals = []models.ScalingSignal{models.SignalCPU}
+	}
 
-	return &Engine{
+	engine := &Engine{
 		config:             cfg,
 		lastScaleUpTimes:   make(map[string]time.Time),
 		lastScaleDownTimes: make(map[string]time.Time),
+		baseMinServers:     cfg.MinServers,
+		baseMaxServers:     cfg.MaxServers,
+		overrides:          make(map[string]Override),
 	}
+	if cfg.Flap.Enabled {
+		engine.flap = NewFlapDetector(cfg.Flap)
+	}
+	return engine
 }
 
 func (e *Engine) Decide(
@@ -78,41 +142,93 @@ func (e *Engine) Decide(
 	prediction *models.Prediction,
 	state *models.ClusterState,
 ) *models.ScalingDecision {
+	e.limitsMu.RLock()
+	defer e.limitsMu.RUnlock()
+
+	// Servers still provisioning count as current capacity, so a long provision
+	// isn't answered with another scale-up once the cooldown expires
 	decision := &models.ScalingDecision{
-		ClusterID:      analyzed.ClusterID,
-		Timestamp:      time.Now(),
-		CurrentServers: state.ActiveServers,
-		TargetServers:  state.ActiveServers,
-		Action:         models.ActionMaintain,
-	}
+		ClusterID:           analyzed.ClusterID,
+		Timestamp:           time.Now(),
+		CurrentServers:      state.CommittedServers(),
+		TargetServers:       state.CommittedServers(),
+		ProvisioningServers: state.ProvisioningCnt,
+		DrainingServers:     state.DrainingCount,
+		Action:              models.ActionMaintain,
+		Prediction:          prediction,
+	}
+	var damping *models.DampingState
+	if e.flap != nil {
+		current := e.withDamping(e.flap.Evaluate(analyzed.ClusterID, time.Now()))
+		damping = &current
+	}
+	decision.Trace = e.newTrace(analyzed, prediction, state)
+	decision.Trace.Damping = damping
+	trace := decision.Trace
 
 	// Emergency override - bypass cooldown for critical CPU
-	if analyzed.AvgCPU >= e.config.EmergencyCPUThreshold {
-		return e.createScaleUpDecision(decision, state, 3, "emergency_cpu_critical", true)
+	if trace.Check("emergency_cpu", analyzed.SignalCPU() >= e.config.EmergencyCPUThreshold, analyzed.SignalCPU(), e.config.EmergencyCPUThreshold) {
+		delta := 3 - state.ProvisioningCnt
+		if e.coveredByProvisioning(decision, state, delta) {
+			return decision
+		}
+		return e.createScaleUpDecision(decision, state, delta, "emergency_cpu_critical", true)
+	}
+
+	// Limits may have moved under the cluster, e.g. when a schedule starts or ends
+	if limited := e.enforceLimits(decision, state); limited != nil {
+		return limited
+	}
+
+	// Per-cluster policy rules take precedence over the built-in logic
+	var scaleDownBlockedBy string
+	if e.config.Policy != nil {
+		outcome := e.applyPolicy(decision, analyzed, prediction, state)
+		if outcome.decision != nil {
+			return outcome.decision
+		}
+		if e.config.Policy.DisableDefaults {
+			decision.Reason = "no_policy_rule_matched"
+			return decision
+		}
+		scaleDownBlockedBy = outcome.blockScaleDown
+	}
+
+	if e.config.ScalingMode == models.ScalingModeTargetTracking {
+		return e.decideTargetTracking(decision, analyzed, state, scaleDownBlockedBy)
 	}
 
 	// Scale up conditions (check scale-up cooldown)
-	if scaleUp, reason := e.shouldScaleUp(analyzed, prediction, state); scaleUp {
+	if scaleUp, reason := e.shouldScaleUp(analyzed, prediction, state, trace); scaleUp {
 		if e.isInScaleUpCooldown(analyzed.ClusterID) {
 			decision.CooldownActive = true
 			decision.Reason = "in_scale_up_cooldown"
 			logger.WithCluster(analyzed.ClusterID).Debug("Decision: maintain (scale-up cooldown active)")
 			return decision
 		}
-		targetDelta := e.calculateScaleUpDelta(analyzed, state)
-		predictionUsed := prediction != nil && reason == "predicted_spike_proactive"
+		targetDelta := e.calculateScaleUpDelta(analyzed, state, trace)
+		if e.coveredByProvisioning(decision, state, targetDelta) {
+			return decision
+		}
+		predictionUsed := prediction != nil && reason == "predicted_cpu_spike_proactive"
+		if predictionUsed {
+			decision.Confidence = prediction.Confidence
+		}
 		return e.createScaleUpDecision(decision, state, targetDelta, reason, false, predictionUsed)
 	}
 
 	// Scale down conditions (check scale-down cooldown)
-	if scaleDown, reason := e.shouldScaleDown(analyzed, prediction, state); scaleDown {
+	if scaleDown, reason := e.shouldScaleDown(analyzed, prediction, state, trace); scaleDown {
+		if scaleDownBlockedBy != "" {
+			return e.blockScaleDown(decision, scaleDownBlockedBy)
+		}
 		if e.isInScaleDownCooldown(analyzed.ClusterID) {
 			decision.CooldownActive = true
 			decision.Reason = "in_scale_down_cooldown"
 			logger.WithCluster(analyzed.ClusterID).Debug("Decision: maintain (scale-down cooldown active)")
 			return decision
 		}
-		targetDelta := e.calculateScaleDownDelta(analyzed, state)
+		targetDelta := e.calculateScaleDownDelta(analyzed, state, trace)
 		return e.createScaleDownDecision(decision, state, targetDelta, reason)
 	}
 
@@ -125,45 +241,67 @@ func (e *Engine) shouldScaleUp(
 	analyzed *models.AnalyzedMetrics,
 	prediction *models.Prediction,
 	state *models.ClusterState,
+	trace *models.DecisionTrace,
 ) (bool, string) {
 	// Check capacity
-	if !state.CanScaleUp(e.config.MaxServers) {
+	if trace.Check("at_max_servers", !state.CanScaleUp(e.config.MaxServers), state.TotalServers, e.config.MaxServers) {
 		return false, ""
 	}
 
 	// Critical status
-	if analyzed.CPUStatus == models.ThresholdCritical {
+	if trace.Check("cpu_critical", analyzed.CPUStatus == models.ThresholdCritical, analyzed.CPUStatus, nil) {
 		return true, "cpu_critical"
 	}
+	if trace.Check("memory_critical", analyzed.MemoryStatus == models.ThresholdCritical, analyzed.MemoryStatus, nil) {
+		return true, "memory_critical"
+	}
 
 	// Spike detected
-	if analyzed.HasSpike {
-		return true, "spike_detected"
+	if trace.Check("cpu_spike", analyzed.HasSpike, analyzed.SpikePercent, nil) {
+		return true, "cpu_spike_detected"
 	}
+	if trace.Check("cpu_anomaly", analyzed.IsAnomaly, analyzed.AnomalyScore, nil) {
+		return true, "cpu_anomaly_detected"
+	}
+
+	sustainedHigh := sustainedSeconds(analyzed.SustainedHighAt)
+	sustainedHighRequired := e.config.SustainedHighDuration.Seconds()
 
 	// Sustained high CPU with rising trend
-	if analyzed.CPUStatus == models.ThresholdWarning && analyzed.Trend == models.TrendRising {
-		if analyzed.SustainedHighAt != nil {
-			duration := time.Since(*analyzed.SustainedHighAt)
-			if duration >= e.config.SustainedHighDuration {
-				return true, "sustained_high_rising"
-			}
+	if trace.Check("cpu_warning_rising_trend",
+		analyzed.CPUStatus == models.ThresholdWarning && analyzed.Trend == models.TrendRising, analyzed.Trend, nil) {
+		if trace.Check("sustained_high_cpu_rising",
+			analyzed.SustainedHighAt != nil && sustainedHigh >= sustainedHighRequired, sustainedHigh, sustainedHighRequired) {
+			return true, "sustained_high_cpu_rising"
 		}
-		return true, "warning_rising_trend"
+		return true, "cpu_warning_rising_trend"
 	}
 
 	// Sustained high CPU
-	if analyzed.SustainedHighAt != nil {
-		duration := time.Since(*analyzed.SustainedHighAt)
-		if duration >= e.config.SustainedHighDuration {
-			return true, "sustained_high_cpu"
-		}
+	if trace.Check("sustained_high_cpu",
+		analyzed.SustainedHighAt != nil && sustainedHigh >= sustainedHighRequired, sustainedHigh, sustainedHighRequired) {
+		return true, "sustained_high_cpu"
+	}
+
+	// Sustained memory pressure
+	sustainedMemory := sustainedSeconds(analyzed.SustainedMemoryHighAt)
+	if trace.Check("sustained_high_memory",
+		analyzed.SustainedMemoryHighAt != nil && analyzed.AvgMemory >= e.config.MemoryHighThreshold &&
+			sustainedMemory >= sustainedHighRequired, sustainedMemory, sustainedHighRequired) {
+		return true, "sustained_high_memory"
+	}
+
+	// Custom metrics at or above their high threshold
+	if reason := e.customHigh(analyzed, trace); reason != "" {
+		return true, reason
 	}
 
 	// Proactive scaling based on prediction
-	if prediction != nil && prediction.IsHighConfidence(0.7) {
-		if prediction.PredictedCPU >= e.config.CPUHighThreshold {
-			return true, "predicted_spike_proactive"
+	if prediction != nil && trace.Check("prediction_confident",
+		prediction.IsHighConfidence(e.config.PredictionMinConfidence), prediction.Confidence, e.config.PredictionMinConfidence) {
+		if trace.Check("predicted_cpu_high",
+			prediction.PredictedCPU >= e.config.CPUHighThreshold, prediction.PredictedCPU, e.config.CPUHighThreshold) {
+			return true, "predicted_cpu_spike_proactive"
 		}
 	}
 
@@ -174,67 +312,274 @@ func (e *Engine) shouldScaleDown(
 	analyzed *models.AnalyzedMetrics,
 	prediction *models.Prediction,
 	state *models.ClusterState,
+	trace *models.DecisionTrace,
 ) (bool, string) {
 	// Check capacity
-	if !state.CanScaleDown(e.config.MinServers) {
+	if trace.Check("at_min_servers", !state.CanScaleDown(e.config.MinServers), state.ActiveServers, e.config.MinServers) {
 		return false, ""
 	}
 
 	// Don't scale down if trend is rising
-	if analyzed.Trend == models.TrendRising {
+	if trace.Check("trend_rising", analyzed.Trend == models.TrendRising, analyzed.Trend, nil) {
+		return false, ""
+	}
+
+	// Don't scale down a memory-bound cluster, or one that would become memory-bound
+	// once the remaining servers absorb the removed server's share
+	if trace.Check("memory_high", analyzed.AvgMemory >= e.config.MemoryHighThreshold, analyzed.AvgMemory, e.config.MemoryHighThreshold) {
+		return false, ""
+	}
+	if state.ActiveServers > 1 {
+		projected := analyzed.AvgMemory * float64(state.ActiveServers) / float64(state.ActiveServers-1)
+		if trace.Check("projected_memory_high", projected >= e.config.MemoryHighThreshold, projected, e.config.MemoryHighThreshold) {
+			return false, ""
+		}
+	}
+
+	// Custom metrics must come down to their low threshold first
+	if e.customNotLow(analyzed, trace) {
 		return false, ""
 	}
 
 	// Don't scale down if prediction shows upcoming spike
-	if prediction != nil && prediction.IsHighConfidence(0.7) {
-		if prediction.PredictedCPU >= e.config.CPUHighThreshold {
+	if prediction != nil && prediction.IsHighConfidence(e.config.PredictionMinConfidence) {
+		if trace.Check("predicted_cpu_high",
+			prediction.PredictedCPU >= e.config.CPUHighThreshold, prediction.PredictedCPU, e.config.CPUHighThreshold) {
 			return false, ""
 		}
 	}
 
 	// Sustained low CPU
-	if analyzed.SustainedLowAt != nil {
-		duration := time.Since(*analyzed.SustainedLowAt)
-		if duration >= e.config.SustainedLowDuration && analyzed.AvgCPU < e.config.CPULowThreshold {
-			return true, "sustained_low_cpu"
-		}
+	lowThreshold := e.cpuLowThreshold(analyzed.ClusterID)
+	sustainedLow := sustainedSeconds(analyzed.SustainedLowAt)
+	sustainedLowRequired := e.config.SustainedLowDuration.Seconds()
+	if trace.Check("sustained_low_cpu",
+		analyzed.SustainedLowAt != nil && sustainedLow >= sustainedLowRequired && analyzed.SignalCPU() < lowThreshold,
+		sustainedLow, sustainedLowRequired) {
+		return true, "sustained_low_cpu"
 	}
 
 	// Very low CPU with stable or falling trend
-	if analyzed.AvgCPU < e.config.CPULowThreshold && 
-		(analyzed.Trend == models.TrendFalling || analyzed.Trend == models.TrendStable) {
+	if trace.Check("low_cpu_stable_or_falling", analyzed.SignalCPU() < lowThreshold &&
+		(analyzed.Trend == models.TrendFalling || analyzed.Trend == models.TrendStable), analyzed.SignalCPU(), lowThreshold) {
 		return true, "low_cpu_stable_or_falling"
 	}
 
 	return false, ""
 }
 
-func (e *Engine) calculateScaleUpDelta(analyzed *models.AnalyzedMetrics, state *models.ClusterState) int {
-	if analyzed.AvgCPU >= e.config.EmergencyCPUThreshold {
-		return e.config.MaxScaleStep
+func (e *Engine) decideTargetTracking(
+	decision *models.ScalingDecision,
+	analyzed *models.AnalyzedMetrics,
+	state *models.ClusterState,
+	scaleDownBlockedBy string,
+) *models.ScalingDecision {
+	trace := decision.Trace
+	desired, signal := e.desiredServers(analyzed, state, trace)
+	if desired < e.config.MinServers {
+		trace.Clamp("min_servers", desired, e.config.MinServers)
+		desired = e.config.MinServers
+	}
+	if desired > e.config.MaxServers {
+		trace.Clamp("max_servers", desired, e.config.MaxServers)
+		desired = e.config.MaxServers
 	}
 
-	// Calculate based on current vs target CPU
-	if analyzed.AvgCPU > 0 && state.ActiveServers > 0 {
-		ratio := analyzed.AvgCPU / e.config.TargetCPU
-		idealServers := int(float64(state.ActiveServers) * ratio)
-		delta := idealServers - state.ActiveServers
+	reason := "target_tracking_" + string(signal)
+	committed := state.CommittedServers()
 
-		if delta < 1 {
-			delta = 1
+	switch {
+	case desired > committed:
+		if e.isInScaleUpCooldown(analyzed.ClusterID) {
+			decision.CooldownActive = true
+			decision.Reason = "in_scale_up_cooldown"
+			return decision
 		}
+		delta := desired - committed
 		if delta > e.config.MaxScaleStep {
+			trace.Clamp("max_scale_step", delta, e.config.MaxScaleStep)
+			delta = e.config.MaxScaleStep
+		}
+		return e.createScaleUpDecision(decision, state, delta, reason, false)
+
+	case desired > state.ActiveServers && e.coveredByProvisioning(decision, state, desired-committed):
+		return decision
+
+	case desired < committed:
+		if scaleDownBlockedBy != "" {
+			return e.blockScaleDown(decision, scaleDownBlockedBy)
+		}
+		if e.isInScaleDownCooldown(analyzed.ClusterID) {
+			decision.CooldownActive = true
+			decision.Reason = "in_scale_down_cooldown"
+			return decision
+		}
+		delta := e.limitScaleDown(committed-desired, committed, trace)
+		return e.createScaleDownDecision(decision, state, delta, reason)
+	}
+
+	decision.Reason = "at_target_" + string(signal)
+	logger.WithCluster(analyzed.ClusterID).Debugf("Decision: maintain (at target, signal: %s)", signal)
+	return decision
+}
+
+// desiredServers returns the server count that brings every configured signal
+// to its target, along with the signal that demanded the most servers
+func (e *Engine) desiredServers(
+	analyzed *models.AnalyzedMetrics,
+	state *models.ClusterState,
+	trace *models.DecisionTrace,
+) (int, models.ScalingSignal) {
+	servers := float64(state.ActiveServers)
+	if servers == 0 {
+		servers = float64(analyzed.ServerCount)
+	}
+
+	desired := 0
+	driver := e.config.ScalingSignals[0]
+	consider := func(signal models.ScalingSignal, ideal, target float64) {
+		// Tolerate float noise so a signal sitting exactly on target doesn't round up
+		count := int(math.Ceil(ideal - 1e-9))
+
+		// Matched marks the signal demanding the most servers so far
+		if trace.Check("desired_servers_"+string(signal), count > desired, count, target) {
+			desired = count
+			driver = signal
+		}
+	}
+
+	for _, signal := range e.config.ScalingSignals {
+		var ideal, target float64
+		switch signal {
+		case models.SignalCPU:
+			ideal = analyzed.SignalCPU() * servers / e.config.TargetCPU
+			target = e.config.TargetCPU
+		case models.SignalMemory:
+			ideal = analyzed.AvgMemory * servers / e.config.TargetMemory
+			target = e.config.TargetMemory
+		case models.SignalLoad:
+			if e.config.TargetLoadPerServer <= 0 {
+				continue
+			}
+			ideal = analyzed.TotalLoad / e.config.TargetLoadPerServer
+			target = e.config.TargetLoadPerServer
+		default:
+			continue
+		}
+		consider(signal, ideal, target)
+	}
+
+	for _, signal := range e.config.CustomSignals {
+		value, ok := customSignalValue(analyzed, signal)
+		if !ok || signal.Target <= 0 {
+			continue
+		}
+		consider(signal.Signal(), customIdealServers(signal, value, servers), signal.Target)
+	}
+
+	return desired, driver
+}
+
+// coveredByProvisioning turns a scale-up into a maintain when the servers already
+// provisioning meet the demand, i.e. delta left nothing more to add
+func (e *Engine) coveredByProvisioning(decision *models.ScalingDecision, state *models.ClusterState, delta int) bool {
+	if !decision.Trace.Check("provisioning_in_flight", delta < 1, state.ProvisioningCnt, nil) {
+		return false
+	}
+	decision.Reason = "provisioning_in_flight"
+	logger.WithCluster(decision.ClusterID).Debugf("Decision: maintain (%d servers already provisioning)", state.ProvisioningCnt)
+	return true
+}
+
+// blockScaleDown turns a scale-down into a maintain attributed to the vetoing policy rule
+func (e *Engine) blockScaleDown(decision *models.ScalingDecision, rule string) *models.ScalingDecision {
+	decision.Rule = rule
+	decision.Reason = "policy:" + rule
+	logger.WithCluster(decision.ClusterID).Debugf("Decision: maintain (scale-down blocked by policy rule %q)", rule)
+	return decision
+}
+
+// calculateScaleUpDelta returns how many servers to add on top of those already
+// provisioning. Zero or less means the in-flight servers cover the demand.
+func (e *Engine) calculateScaleUpDelta(analyzed *models.AnalyzedMetrics, state *models.ClusterState, trace *models.DecisionTrace) int {
+	if analyzed.SignalCPU() >= e.config.EmergencyCPUThreshold {
+		return e.config.MaxScaleStep - state.ProvisioningCnt
+	}
+
+	// Calculate based on whichever resource is furthest above its target. Only active
+	// servers report load, so they are the base; provisioning ones will share it.
+	if (analyzed.SignalCPU() > 0 || analyzed.AvgMemory > 0) && state.ActiveServers > 0 {
+		ratio := math.Max(analyzed.SignalCPU()/e.config.TargetCPU, analyzed.AvgMemory/e.config.TargetMemory)
+		idealServers := int(float64(state.ActiveServers) * ratio)
+		if idealServers <= state.ActiveServers {
+			idealServers = state.ActiveServers + 1
+		}
+		delta := idealServers - state.CommittedServers()
+
+		if delta > e.config.MaxScaleStep {
+			trace.Clamp("max_scale_step", delta, e.config.MaxScaleStep)
 			delta = e.config.MaxScaleStep
 		}
 		return delta
 	}
 
-	return 1
+	return 1 - state.ProvisioningCnt
 }
 
-func (e *Engine) calculateScaleDownDelta(analyzed *models.AnalyzedMetrics, state *models.ClusterState) int {
-	// Conservative scale down - always 1 at a time
-	return 1
+// limitScaleDown caps the servers removed in one step by the scale-down step and
+// the largest share of the committed servers that may go at once
+func (e *Engine) limitScaleDown(delta, current int, trace *models.DecisionTrace) int {
+	if delta > e.config.MaxScaleDownStep {
+		trace.Clamp("max_scale_down_step", delta, e.config.MaxScaleDownStep)
+		delta = e.config.MaxScaleDownStep
+	}
+	maxByPercent := int(float64(current) * e.config.MaxScaleDownPercent / 100)
+	if maxByPercent < 1 {
+		maxByPercent = 1
+	}
+	if delta > maxByPercent {
+		trace.Clamp("max_scale_down_percent", delta, maxByPercent)
+		delta = maxByPercent
+	}
+	return delta
+}
+
+func (e *Engine) calculateScaleDownDelta(analyzed *models.AnalyzedMetrics, state *models.ClusterState, trace *models.DecisionTrace) int {
+	if e.config.ScaleDownMode != ScaleDownProportional || state.ActiveServers <= 1 {
+		// Conservative scale down - always 1 at a time
+		return 1
+	}
+
+	// Size for whichever resource needs the most servers at its target. Load is
+	// measured on the active servers, but provisioning ones count towards the result.
+	active := state.ActiveServers
+	current := state.CommittedServers()
+	ratio := math.Max(analyzed.SignalCPU()/e.config.TargetCPU, analyzed.AvgMemory/e.config.TargetMemory)
+	idealServers := int(math.Ceil(float64(active) * ratio))
+	if idealServers < 1 {
+		idealServers = 1
+	}
+	delta := e.limitScaleDown(current-idealServers, current, trace)
+
+	// Back off until the remaining servers absorb the load with headroom below the high thresholds
+	unsafe := delta
+	for delta > 1 && !e.isSafeScaleDown(analyzed, active, current-delta) {
+		delta--
+	}
+	trace.Clamp("scale_down_safety_margin", unsafe, delta)
+	if delta < 1 {
+		delta = 1
+	}
+
+	return delta
+}
+
+// isSafeScaleDown reports whether CPU and memory measured on active servers, projected
+// onto the remaining ones, stay at least ScaleDownSafetyMargin below their high thresholds
+func (e *Engine) isSafeScaleDown(analyzed *models.AnalyzedMetrics, active, remaining int) bool {
+	scale := float64(active) / float64(remaining)
+	return analyzed.SignalCPU()*scale < e.config.CPUHighThreshold-e.config.ScaleDownSafetyMargin &&
+		analyzed.AvgMemory*scale < e.config.MemoryHighThreshold-e.config.ScaleDownSafetyMargin
 }
 
 func (e *Engine) createScaleUpDecision(
@@ -245,10 +590,11 @@ func (e *Engine) createScaleUpDecision(
 	isEmergency bool,
 	predictionUsed ...bool,
 ) *models.ScalingDecision {
-	targetServers := state.ActiveServers + delta
+	targetServers := state.CommittedServers() + delta
 	maxAllowed := e.config.MaxServers
 
 	if targetServers > maxAllowed {
+		decision.Trace.Clamp("max_servers", targetServers, maxAllowed)
 		targetServers = maxAllowed
 	}
 
@@ -275,10 +621,21 @@ func (e *Engine) createScaleDownDecision(
 	delta int,
 	reason string,
 ) *models.ScalingDecision {
-	targetServers := state.ActiveServers - delta
+	// Only active servers can be removed; provisioning ones are never cancelled
+	if removable := state.ActiveServers - 1; delta > removable {
+		decision.Trace.Clamp("active_servers", delta, removable)
+		delta = removable
+	}
+	if delta < 1 {
+		decision.Reason = reason
+		return decision
+	}
+
+	targetServers := state.CommittedServers() - delta
 	minAllowed := e.config.MinServers
 
 	if targetServers < minAllowed {
+		decision.Trace.Clamp("min_servers", targetServers, minAllowed)
 		targetServers = minAllowed
 	}
 
@@ -307,6 +664,8 @@ func (e *Engine) isInScaleUpCooldown(clusterID string) bool {
 }
 
 func (e *Engine) isInScaleDownCooldown(clusterID string) bool {
+	cooldown := e.scaleDownCooldown(clusterID)
+
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
@@ -315,7 +674,7 @@ func (e *Engine) isInScaleDownCooldown(clusterID string) bool {
 		return false
 	}
 
-	return time.Since(lastScale) < e.config.ScaleDownCooldownPeriod
+	return time.Since(lastScale) < cooldown
 }
 
 func (e *Engine) RecordScaleUp(clusterID string) {
@@ -338,6 +697,15 @@ func (e *Engine) RecordScaling(clusterID string) {
 	e.lastScaleDownTimes[clusterID] = time.Now()
 }
 
+// RecordScalingAction starts the cooldowns after an executed scaling action and
+// feeds it to the flap detector
+func (e *Engine) RecordScalingAction(clusterID string, action models.ScalingAction) {
+	e.RecordScaling(clusterID)
+	if e.flap != nil {
+		e.flap.Record(clusterID, action, time.Now())
+	}
+}
+
 func (e *Engine) ResetCooldown(clusterID string) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -345,6 +713,27 @@ func (e *Engine) ResetCooldown(clusterID string) {
 	delete(e.lastScaleDownTimes, clusterID)
 }
 
+// cooldownRemaining returns how much of the scale-up and scale-down cooldowns is left
+func (e *Engine) cooldownRemaining(clusterID string) (time.Duration, time.Duration) {
+	downCooldown := e.scaleDownCooldown(clusterID)
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var up, down time.Duration
+	if last, exists := e.lastScaleUpTimes[clusterID]; exists {
+		if elapsed := time.Since(last); elapsed < e.config.CooldownPeriod {
+			up = e.config.CooldownPeriod - elapsed
+		}
+	}
+	if last, exists := e.lastScaleDownTimes[clusterID]; exists {
+		if elapsed := time.Since(last); elapsed < downCooldown {
+			down = downCooldown - elapsed
+		}
+	}
+	return up, down
+}
+
 func (e *Engine) GetCooldownRemaining(clusterID string) time.Duration {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
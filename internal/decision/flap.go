@@ -0,0 +1,194 @@
+package decision
+
+import (
+	"sync"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+type FlapConfig struct {
+	Enabled            bool
+	Window             time.Duration // reversals are counted over this window
+	Reversals          int           // reversals within Window that trigger damping
+	CooldownMultiplier float64       // scale-down cooldown multiplier while damped
+	HysteresisMargin   float64       // CPU points the scale-down threshold drops while damped
+	StableFor          time.Duration // time without a reversal before damping relaxes
+}
+
+type scalingMove struct {
+	action models.ScalingAction
+	at     time.Time
+}
+
+type flapHistory struct {
+	moves        []scalingMove
+	lastReversal time.Time
+	dampedSince  time.Time
+}
+
+// FlapDetector counts scaling direction reversals per cluster and switches damping
+// on when a cluster keeps bouncing between scale-up and scale-down
+type FlapDetector struct {
+	config   FlapConfig
+	clusters map[string]*flapHistory
+	mu       sync.Mutex
+}
+
+func NewFlapDetector(cfg FlapConfig) *FlapDetector {
+	if cfg.Window == 0 {
+		cfg.Window = 30 * time.Minute
+	}
+	if cfg.Reversals == 0 {
+		cfg.Reversals = 3
+	}
+	if cfg.CooldownMultiplier == 0 {
+		cfg.CooldownMultiplier = 3.0
+	}
+	if cfg.HysteresisMargin == 0 {
+		cfg.HysteresisMargin = 10.0
+	}
+	if cfg.StableFor == 0 {
+		cfg.StableFor = cfg.Window
+	}
+
+	return &FlapDetector{
+		config:   cfg,
+		clusters: make(map[string]*flapHistory),
+	}
+}
+
+// Record adds an executed scaling action to the cluster's history
+func (d *FlapDetector) Record(clusterID string, action models.ScalingAction, at time.Time) {
+	if action != models.ActionScaleUp && action != models.ActionScaleDown {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	h, exists := d.clusters[clusterID]
+	if !exists {
+		h = &flapHistory{}
+		d.clusters[clusterID] = h
+	}
+
+	if n := len(h.moves); n > 0 && h.moves[n-1].action != action {
+		h.lastReversal = at
+	}
+	h.moves = append(h.moves, scalingMove{action: action, at: at})
+	d.prune(h, at)
+
+	if h.dampedSince.IsZero() && reversals(h.moves) >= d.config.Reversals {
+		h.dampedSince = at
+	}
+}
+
+// Evaluate returns the cluster's damping state as of now, relaxing damping once
+// no reversal has happened for StableFor
+func (d *FlapDetector) Evaluate(clusterID string, now time.Time) models.DampingState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	h, exists := d.clusters[clusterID]
+	if !exists {
+		return models.DampingState{}
+	}
+
+	d.prune(h, now)
+	if !h.dampedSince.IsZero() && now.Sub(h.lastReversal) >= d.config.StableFor {
+		// Start counting afresh so the reversals that caused damping don't re-trigger it
+		h.dampedSince = time.Time{}
+		h.moves = nil
+	}
+
+	return d.state(h)
+}
+
+// State returns the cluster's damping state without relaxing it
+func (d *FlapDetector) State(clusterID string) models.DampingState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	h, exists := d.clusters[clusterID]
+	if !exists {
+		return models.DampingState{}
+	}
+	return d.state(h)
+}
+
+func (d *FlapDetector) Reset(clusterID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.clusters, clusterID)
+}
+
+func (d *FlapDetector) state(h *flapHistory) models.DampingState {
+	state := models.DampingState{Reversals: reversals(h.moves)}
+	if !h.dampedSince.IsZero() {
+		since := h.dampedSince
+		relaxesAt := h.lastReversal.Add(d.config.StableFor)
+		state.Active = true
+		state.Since = &since
+		state.RelaxesAt = &relaxesAt
+	}
+	return state
+}
+
+// prune drops moves that fell out of the window. Callers must hold mu.
+func (d *FlapDetector) prune(h *flapHistory, now time.Time) {
+	cutoff := now.Add(-d.config.Window)
+	i := 0
+	for i < len(h.moves) && h.moves[i].at.Before(cutoff) {
+		i++
+	}
+	h.moves = h.moves[i:]
+}
+
+func reversals(moves []scalingMove) int {
+	count := 0
+	for i := 1; i < len(moves); i++ {
+		if moves[i].action != moves[i-1].action {
+			count++
+		}
+	}
+	return count
+}
+
+// Damping returns the cluster's flap damping state, zero when damping is disabled
+func (e *Engine) Damping(clusterID string) models.DampingState {
+	if e.flap == nil {
+		return models.DampingState{}
+	}
+	return e.withDamping(e.flap.State(clusterID))
+}
+
+// withDamping adds the limits that apply while state is active
+func (e *Engine) withDamping(state models.DampingState) models.DampingState {
+	if state.Active {
+		state.ScaleDownCooldownSeconds = e.dampedScaleDownCooldown().Seconds()
+		state.CPULowThreshold = e.config.CPULowThreshold - e.flap.config.HysteresisMargin
+	}
+	return state
+}
+
+func (e *Engine) dampedScaleDownCooldown() time.Duration {
+	return time.Duration(float64(e.config.ScaleDownCooldownPeriod) * e.flap.config.CooldownMultiplier)
+}
+
+// scaleDownCooldown returns the scale-down cooldown, extended while the cluster is damped
+func (e *Engine) scaleDownCooldown(clusterID string) time.Duration {
+	if e.flap != nil && e.flap.State(clusterID).Active {
+		return e.dampedScaleDownCooldown()
+	}
+	return e.config.ScaleDownCooldownPeriod
+}
+
+// cpuLowThreshold returns the CPU level scale-down waits for. Damping widens the
+// hysteresis band by moving it down, so a flapping cluster keeps its capacity longer.
+func (e *Engine) cpuLowThreshold(clusterID string) float64 {
+	if e.flap != nil && e.flap.State(clusterID).Active {
+		return e.config.CPULowThreshold - e.flap.config.HysteresisMargin
+	}
+	return e.config.CPULowThreshold
+}
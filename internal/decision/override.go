@@ -0,0 +1,124 @@
+package decision
+
+import (
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// Override temporarily replaces the engine's server limits, e.g. while a scaling
+// schedule is active. DesiredServers pins the cluster to a fixed size.
+type Override struct {
+	ID             string
+	Name           string
+	MinServers     *int
+	MaxServers     *int
+	DesiredServers *int
+	StartedAt      time.Time
+	Until          time.Time
+}
+
+// ApplyOverride installs or refreshes an override. When several are active the
+// most recently started one wins.
+func (e *Engine) ApplyOverride(o Override) {
+	e.limitsMu.Lock()
+	defer e.limitsMu.Unlock()
+
+	e.overrides[o.ID] = o
+	e.applyLimits()
+}
+
+// ClearOverride removes an override and restores the limits it replaced
+func (e *Engine) ClearOverride(id string) {
+	e.limitsMu.Lock()
+	defer e.limitsMu.Unlock()
+
+	delete(e.overrides, id)
+	e.applyLimits()
+}
+
+// ActiveOverride returns the override currently in effect, if any
+func (e *Engine) ActiveOverride() (Override, bool) {
+	e.limitsMu.RLock()
+	defer e.limitsMu.RUnlock()
+
+	if e.activeOverride == nil {
+		return Override{}, false
+	}
+	return *e.activeOverride, true
+}
+
+// applyLimits recomputes MinServers/MaxServers from the base limits and the winning override.
+// Callers must hold limitsMu for writing.
+func (e *Engine) applyLimits() {
+	e.config.MinServers = e.baseMinServers
+	e.config.MaxServers = e.baseMaxServers
+	e.activeOverride = nil
+
+	for id := range e.overrides {
+		o := e.overrides[id]
+		if e.activeOverride == nil || o.StartedAt.After(e.activeOverride.StartedAt) ||
+			(o.StartedAt.Equal(e.activeOverride.StartedAt) && o.ID > e.activeOverride.ID) {
+			e.activeOverride = &o
+		}
+	}
+	if e.activeOverride == nil {
+		return
+	}
+
+	o := e.activeOverride
+	if o.DesiredServers != nil {
+		e.config.MinServers = *o.DesiredServers
+		e.config.MaxServers = *o.DesiredServers
+		return
+	}
+	if o.MinServers != nil {
+		e.config.MinServers = *o.MinServers
+		if e.config.MaxServers < e.config.MinServers {
+			e.config.MaxServers = e.config.MinServers
+		}
+	}
+	if o.MaxServers != nil {
+		e.config.MaxServers = *o.MaxServers
+		if e.config.MinServers > e.config.MaxServers {
+			e.config.MinServers = e.config.MaxServers
+		}
+	}
+}
+
+// enforceLimits moves a cluster that sits outside its server limits back inside them.
+// Cooldowns don't apply: limits are hard bounds, and servers still provisioning
+// count towards the minimum so a slow scale-up isn't repeated every cycle.
+func (e *Engine) enforceLimits(decision *models.ScalingDecision, state *models.ClusterState) *models.ScalingDecision {
+	reason := ""
+	if e.activeOverride != nil {
+		reason = "schedule:" + e.activeOverride.Name
+	}
+
+	deficit := e.config.MinServers - state.TotalServers
+	if decision.Trace.Check("below_min_servers",
+		deficit > 0 && state.ActiveServers < e.config.MinServers, state.TotalServers, e.config.MinServers) {
+		if reason == "" {
+			reason = "below_min_servers"
+		}
+		if deficit > e.config.MaxScaleStep {
+			decision.Trace.Clamp("max_scale_step", deficit, e.config.MaxScaleStep)
+			deficit = e.config.MaxScaleStep
+		}
+		return e.createScaleUpDecision(decision, state, deficit, reason, false)
+	}
+
+	excess := state.CommittedServers() - e.config.MaxServers
+	if decision.Trace.Check("above_max_servers", excess > 0, state.CommittedServers(), e.config.MaxServers) {
+		if reason == "" {
+			reason = "above_max_servers"
+		}
+		if excess > e.config.MaxScaleStep {
+			decision.Trace.Clamp("max_scale_step", excess, e.config.MaxScaleStep)
+			excess = e.config.MaxScaleStep
+		}
+		return e.createScaleDownDecision(decision, state, excess, reason)
+	}
+
+	return nil
+}
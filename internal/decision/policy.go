@@ -0,0 +1,223 @@
+package decision
+
+import (
+	"math"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// policyOutcome is the result of evaluating a cluster's scaling policy
+type policyOutcome struct {
+	decision       *models.ScalingDecision
+	blockScaleDown string // name of the rule vetoing scale-down, if any
+}
+
+// applyPolicy evaluates the policy rules in order. The first matching rule with a
+// scaling action decides; matching block_scale_down rules are collected along the way.
+func (e *Engine) applyPolicy(
+	decision *models.ScalingDecision,
+	analyzed *models.AnalyzedMetrics,
+	prediction *models.Prediction,
+	state *models.ClusterState,
+) policyOutcome {
+	var outcome policyOutcome
+
+	for _, rule := range e.config.Policy.Rules {
+		if !decision.Trace.Check("policy_rule:"+rule.Name, ruleMatches(rule, analyzed, prediction), nil, nil) {
+			continue
+		}
+
+		if rule.Action.Type == models.PolicyActionBlockScaleDown {
+			if outcome.blockScaleDown == "" {
+				outcome.blockScaleDown = rule.Name
+			}
+			continue
+		}
+
+		target := e.policyTarget(rule.Action, state, decision.Trace)
+		decision.Rule = rule.Name
+		reason := "policy:" + rule.Name
+		if prediction != nil && ruleUsesPrediction(rule) {
+			decision.PredictionUsed = true
+			decision.Confidence = prediction.Confidence
+		}
+
+		current := state.CommittedServers()
+		switch {
+		case target > current:
+			if e.isInScaleUpCooldown(analyzed.ClusterID) {
+				decision.CooldownActive = true
+				decision.Reason = "in_scale_up_cooldown"
+				outcome.decision = decision
+				return outcome
+			}
+			outcome.decision = e.createScaleUpDecision(decision, state, target-current, reason, false)
+		case target < current:
+			if outcome.blockScaleDown != "" {
+				outcome.decision = e.blockScaleDown(decision, outcome.blockScaleDown)
+				return outcome
+			}
+			if e.isInScaleDownCooldown(analyzed.ClusterID) {
+				decision.CooldownActive = true
+				decision.Reason = "in_scale_down_cooldown"
+				outcome.decision = decision
+				return outcome
+			}
+			outcome.decision = e.createScaleDownDecision(decision, state, current-target, reason)
+		default:
+			decision.Reason = reason
+			outcome.decision = decision
+		}
+
+		logger.WithCluster(analyzed.ClusterID).Debugf("Policy rule %q fired", rule.Name)
+		return outcome
+	}
+
+	return outcome
+}
+
+// policyTarget converts a rule action into a server count within min/max and MaxScaleStep
+func (e *Engine) policyTarget(action models.PolicyAction, state *models.ClusterState, trace *models.DecisionTrace) int {
+	current := state.CommittedServers()
+
+	var target int
+	switch action.Type {
+	case models.PolicyActionAdd:
+		target = current + int(action.Value)
+	case models.PolicyActionAddPercent:
+		delta := int(math.Ceil(float64(current) * action.Value / 100))
+		if delta < 1 {
+			delta = 1
+		}
+		target = current + delta
+	case models.PolicyActionSetTarget:
+		target = int(action.Value)
+	default:
+		return current
+	}
+
+	if target > current+e.config.MaxScaleStep {
+		trace.Clamp("max_scale_step", target, current+e.config.MaxScaleStep)
+		target = current + e.config.MaxScaleStep
+	}
+	if target < current-e.config.MaxScaleStep {
+		trace.Clamp("max_scale_step", target, current-e.config.MaxScaleStep)
+		target = current - e.config.MaxScaleStep
+	}
+	if target > e.config.MaxServers {
+		trace.Clamp("max_servers", target, e.config.MaxServers)
+		target = e.config.MaxServers
+	}
+	if target < e.config.MinServers {
+		trace.Clamp("min_servers", target, e.config.MinServers)
+		target = e.config.MinServers
+	}
+	return target
+}
+
+func ruleMatches(rule models.PolicyRule, analyzed *models.AnalyzedMetrics, prediction *models.Prediction) bool {
+	for _, condition := range rule.Conditions {
+		if !conditionMatches(condition, analyzed, prediction) {
+			return false
+		}
+	}
+	return true
+}
+
+func ruleUsesPrediction(rule models.PolicyRule) bool {
+	for _, condition := range rule.Conditions {
+		if condition.Field == models.PolicyFieldPredictedCPU || condition.Field == models.PolicyFieldPredictionConfidence {
+			return true
+		}
+	}
+	return false
+}
+
+func conditionMatches(condition models.PolicyCondition, analyzed *models.AnalyzedMetrics, prediction *models.Prediction) bool {
+	switch condition.Field {
+	case models.PolicyFieldTrend:
+		expected, _ := condition.Value.(string)
+		return compareEquality(condition.Operator, string(analyzed.Trend) == expected)
+	case models.PolicyFieldHasSpike:
+		expected, _ := condition.Value.(bool)
+		return compareEquality(condition.Operator, analyzed.HasSpike == expected)
+	case models.PolicyFieldIsAnomaly:
+		expected, _ := condition.Value.(bool)
+		return compareEquality(condition.Operator, analyzed.IsAnomaly == expected)
+	}
+
+	expected, ok := condition.Value.(float64)
+	if !ok {
+		return false
+	}
+
+	var actual float64
+	switch condition.Field {
+	case models.PolicyFieldAvgCPU:
+		actual = analyzed.AvgCPU
+	case models.PolicyFieldAvgMemory:
+		actual = analyzed.AvgMemory
+	case models.PolicyFieldAvgLoad:
+		actual = analyzed.AvgLoad
+	case models.PolicyFieldTotalLoad:
+		actual = analyzed.TotalLoad
+	case models.PolicyFieldSustainedHighSecs:
+		actual = sustainedSeconds(analyzed.SustainedHighAt)
+	case models.PolicyFieldSustainedLowSecs:
+		actual = sustainedSeconds(analyzed.SustainedLowAt)
+	case models.PolicyFieldSustainedMemHighSecs:
+		actual = sustainedSeconds(analyzed.SustainedMemoryHighAt)
+	case models.PolicyFieldSustainedMemLowSecs:
+		actual = sustainedSeconds(analyzed.SustainedMemoryLowAt)
+	case models.PolicyFieldPredictedCPU:
+		// Conditions on a forecast never match when there is no forecast
+		if prediction == nil {
+			return false
+		}
+		actual = prediction.PredictedCPU
+	case models.PolicyFieldPredictionConfidence:
+		if prediction == nil {
+			return false
+		}
+		actual = prediction.Confidence
+	default:
+		return false
+	}
+
+	switch condition.Operator {
+	case models.OperatorGT:
+		return actual > expected
+	case models.OperatorGTE:
+		return actual >= expected
+	case models.OperatorLT:
+		return actual < expected
+	case models.OperatorLTE:
+		return actual <= expected
+	case models.OperatorEQ:
+		return actual == expected
+	case models.OperatorNEQ:
+		return actual != expected
+	default:
+		return false
+	}
+}
+
+func compareEquality(operator models.PolicyOperator, equal bool) bool {
+	switch operator {
+	case models.OperatorEQ:
+		return equal
+	case models.OperatorNEQ:
+		return !equal
+	default:
+		return false
+	}
+}
+
+func sustainedSeconds(since *time.Time) float64 {
+	if since == nil {
+		return 0
+	}
+	return time.Since(*since).Seconds()
+}
@@ -0,0 +1,69 @@
+package decision
+
+import (
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// newTrace snapshots the inputs, limits and cooldown state a decision starts from.
+// Callers must hold limitsMu.
+func (e *Engine) newTrace(
+	analyzed *models.AnalyzedMetrics,
+	prediction *models.Prediction,
+	state *models.ClusterState,
+) *models.DecisionTrace {
+	trace := &models.DecisionTrace{
+		Inputs: models.DecisionInputs{
+			AvgCPU:              analyzed.AvgCPU,
+			CPUStatistic:        analyzed.CPUStatistic,
+			SignalCPU:           analyzed.SignalCPU(),
+			AvgMemory:           analyzed.AvgMemory,
+			AvgLoad:             analyzed.AvgLoad,
+			TotalLoad:           analyzed.TotalLoad,
+			CPUStatus:           analyzed.CPUStatus,
+			MemoryStatus:        analyzed.MemoryStatus,
+			Trend:               analyzed.Trend,
+			HasSpike:            analyzed.HasSpike,
+			IsAnomaly:           analyzed.IsAnomaly,
+			AnomalyScore:        analyzed.AnomalyScore,
+			ActiveServers:       state.ActiveServers,
+			TotalServers:        state.TotalServers,
+			ProvisioningServers: state.ProvisioningCnt,
+			DrainingServers:     state.DrainingCount,
+		},
+		Limits: models.DecisionLimits{
+			MinServers:       e.config.MinServers,
+			MaxServers:       e.config.MaxServers,
+			MaxScaleStep:     e.config.MaxScaleStep,
+			MaxScaleDownStep: e.config.MaxScaleDownStep,
+			ScalingMode:      e.config.ScalingMode,
+		},
+		Checks: []models.DecisionCheck{},
+	}
+
+	for _, signal := range e.config.CustomSignals {
+		if value, ok := customSignalValue(analyzed, signal); ok {
+			if trace.Inputs.Custom == nil {
+				trace.Inputs.Custom = make(map[string]float64)
+			}
+			trace.Inputs.Custom[signal.Name] = value
+		}
+	}
+
+	if prediction != nil {
+		trace.Inputs.PredictedCPU = &prediction.PredictedCPU
+		trace.Inputs.PredictionConfidence = &prediction.Confidence
+	}
+	if e.activeOverride != nil {
+		trace.Limits.Override = e.activeOverride.Name
+	}
+
+	upRemaining, downRemaining := e.cooldownRemaining(analyzed.ClusterID)
+	trace.Cooldown = models.CooldownState{
+		ScaleUpActive:             upRemaining > 0,
+		ScaleUpRemainingSeconds:   upRemaining.Seconds(),
+		ScaleDownActive:           downRemaining > 0,
+		ScaleDownRemainingSeconds: downRemaining.Seconds(),
+	}
+
+	return trace
+}
@@ -104,13 +104,24 @@ func allEventTypes() []models.EventType {
 	return []models.EventType{
 		models.EventTypeMetricCollected,
 		models.EventTypeMetricAnalyzed,
+		models.EventTypePredictionMade,
 		models.EventTypeDecisionMade,
+		models.EventTypeDecisionRecorded,
 		models.EventTypeScalingStarted,
 		models.EventTypeScalingComplete,
 		models.EventTypeScalingFailed,
+		models.EventTypeWouldScale,
 		models.EventTypeServerAdded,
 		models.EventTypeServerRemoved,
 		models.EventTypeServerActivated,
+		models.EventTypeScheduleStarted,
+		models.EventTypeScheduleEnded,
+		models.EventTypeScalingRequestCreated,
+		models.EventTypeScalingRequestResolved,
+		models.EventTypeServerHot,
+		models.EventTypeServerStale,
+		models.EventTypeLoadImbalance,
+		models.EventTypeDataQuality,
 		models.EventTypeAlert,
 		models.EventTypeError,
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/OldStager01/cloud-autoscaler/internal/logger"
 	"github.com/OldStager01/cloud-autoscaler/pkg/database"
+	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
 	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 )
 
@@ -72,6 +73,8 @@ func (l *EventLogger) processEvent(event *models.Event) {
 		l.persistScalingEvent(event)
 	case models.EventTypeMetricCollected:
 		l.persistMetrics(event)
+	case models.EventTypePredictionMade:
+		l.persistPrediction(event)
 	}
 }
 
@@ -83,8 +86,8 @@ func (l *EventLogger) persistScalingEvent(event *models.Event) {
 
 	query := `
 		INSERT INTO scaling_events 
-			(cluster_id, timestamp, action, servers_before, servers_after, trigger_reason, prediction_used, confidence, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			(cluster_id, timestamp, action, servers_before, servers_after, trigger_reason, prediction_used, confidence, status, initiated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	var confidence *float64
 	if scalingEvent.Confidence != nil {
@@ -101,6 +104,7 @@ func (l *EventLogger) persistScalingEvent(event *models.Event) {
 		scalingEvent.PredictionUsed,
 		confidence,
 		scalingEvent.Status,
+		scalingEvent.InitiatedBy,
 	)
 
 	if err != nil {
@@ -132,9 +136,82 @@ func (l *EventLogger) persistMetrics(event *models.Event) {
 			logger.Errorf("Failed to persist metrics:  %v", err)
 		}
 	}
+
+	var custom []queries.CustomMetricPoint
+	for _, server := range metrics.Servers {
+		serverID := server.ServerID
+		for name, value := range server.Custom {
+			custom = append(custom, queries.CustomMetricPoint{
+				Time: metrics.Timestamp, ClusterID: metrics.ClusterID, ServerID: &serverID, Name: name, Value: value,
+			})
+		}
+	}
+	for name, value := range metrics.Custom {
+		custom = append(custom, queries.CustomMetricPoint{
+			Time: metrics.Timestamp, ClusterID: metrics.ClusterID, Name: name, Value: value,
+		})
+	}
+	if err := queries.NewMetricsRepository(l.db.DB).InsertCustomBatch(l.ctx, custom); err != nil {
+		logger.Errorf("Failed to persist custom metrics: %v", err)
+	}
 }
 
 func (l *EventLogger) LogToJSON(event *models.Event) string {
 	data, _ := json.Marshal(event)
 	return string(data)
-}
\ No newline at end of file
+}
+
+func (l *EventLogger) persistPrediction(event *models.Event) {
+	prediction, ok := event.Data.(*models.Prediction)
+	if !ok {
+		return
+	}
+
+	// Models without a prediction interval leave both bounds unset
+	var lowerBound, upperBound *float64
+	if prediction.UpperBound > 0 {
+		lowerBound = &prediction.LowerBound
+		upperBound = &prediction.UpperBound
+	}
+
+	query := `
+		INSERT INTO predictions
+			(created_at, cluster_id, forecast_time, predicted_cpu, lower_bound, upper_bound, confidence, model_version, ensemble_member)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := l.db.ExecContext(l.ctx, query,
+		prediction.CreatedAt,
+		prediction.ClusterID,
+		prediction.ForecastTime,
+		prediction.PredictedCPU,
+		lowerBound,
+		upperBound,
+		prediction.Confidence,
+		prediction.ModelVersion,
+		false,
+	)
+
+	if err != nil {
+		logger.Errorf("Failed to persist prediction: %v", err)
+	}
+
+	// Ensemble members are stored as their own rows so each model can be scored
+	// separately, flagged so prediction listings leave them out
+	for _, component := range prediction.Components {
+		_, err := l.db.ExecContext(l.ctx, query,
+			prediction.CreatedAt,
+			prediction.ClusterID,
+			prediction.ForecastTime,
+			component.PredictedCPU,
+			nil,
+			nil,
+			component.Confidence,
+			component.ModelVersion,
+			true,
+		)
+
+		if err != nil {
+			logger.Errorf("Failed to persist prediction component %s: %v", component.ModelVersion, err)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package events
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 )
 
@@ -46,6 +49,12 @@ func (p *Publisher) MetricAnalyzed(clusterID string, analyzed *models.AnalyzedMe
 	p.publish(event)
 }
 
+func (p *Publisher) PredictionMade(clusterID string, prediction *models.Prediction) {
+	event := models.NewEvent(models.EventTypePredictionMade, clusterID, "Prediction made").
+		WithData(prediction)
+	p.publish(event)
+}
+
 func (p *Publisher) DecisionMade(clusterID string, decision *models.ScalingDecision) {
 	msg := "Scaling decision:  " + string(decision.Action)
 	event := models.NewEvent(models.EventTypeDecisionMade, clusterID, msg).
@@ -58,6 +67,14 @@ func (p *Publisher) DecisionMade(clusterID string, decision *models.ScalingDecis
 	p.publish(event)
 }
 
+// DecisionRecorded publishes a decision once the pipeline knows its outcome
+func (p *Publisher) DecisionRecorded(clusterID string, record *models.DecisionRecord) {
+	msg := "Scaling decision " + string(record.Action) + ": " + string(record.Outcome)
+	event := models.NewEvent(models.EventTypeDecisionRecorded, clusterID, msg).
+		WithData(record)
+	p.publish(event)
+}
+
 func (p *Publisher) ScalingStarted(clusterID string, decision *models.ScalingDecision) {
 	msg := "Scaling started: " + string(decision.Action)
 	event := models.NewEvent(models.EventTypeScalingStarted, clusterID, msg).
@@ -83,6 +100,21 @@ func (p *Publisher) ScalingFailed(clusterID string, reason string, err error) {
 	p.publish(event)
 }
 
+// WouldScale publishes the scaling action an observe-mode cluster would have taken
+func (p *Publisher) WouldScale(clusterID string, decision *models.ScalingDecision) {
+	msg := fmt.Sprintf("Would have scaled: %s %d -> %d servers",
+		decision.Action, decision.CurrentServers, decision.TargetServers)
+	event := models.NewEvent(models.EventTypeWouldScale, clusterID, msg).
+		WithData(map[string]interface{}{
+			"action":          decision.Action,
+			"current_servers": decision.CurrentServers,
+			"target_servers":  decision.TargetServers,
+			"delta":           decision.ServerDelta(),
+			"reason":          decision.Reason,
+		})
+	p.publish(event)
+}
+
 func (p *Publisher) ServerAdded(server *models.Server) {
 	event := models.NewEvent(models.EventTypeServerAdded, server.ClusterID, "Server added").
 		WithData(server)
@@ -101,6 +133,83 @@ func (p *Publisher) ServerActivated(server *models.Server) {
 	p.publish(event)
 }
 
+func (p *Publisher) ScheduleStarted(schedule *models.ScalingSchedule, until time.Time) {
+	msg := "Scaling schedule started: " + schedule.Name
+	event := models.NewEvent(models.EventTypeScheduleStarted, schedule.ClusterID, msg).
+		WithData(map[string]interface{}{
+			"schedule": schedule,
+			"until":    until,
+		})
+	p.publish(event)
+}
+
+func (p *Publisher) ScheduleEnded(schedule *models.ScalingSchedule) {
+	msg := "Scaling schedule ended: " + schedule.Name
+	event := models.NewEvent(models.EventTypeScheduleEnded, schedule.ClusterID, msg).
+		WithData(map[string]interface{}{
+			"schedule": schedule,
+		})
+	p.publish(event)
+}
+
+// ScalingRequestCreated announces a scale-up that waits for approval
+func (p *Publisher) ScalingRequestCreated(request *models.ScalingRequest) {
+	msg := fmt.Sprintf("Scale-up %d -> %d servers awaits approval until %s",
+		request.CurrentServers, request.TargetServers, request.ExpiresAt.Format(time.RFC3339))
+	event := models.NewEvent(models.EventTypeScalingRequestCreated, request.ClusterID, msg).
+		WithSeverity(models.SeverityWarning).
+		WithData(request)
+	p.publish(event)
+}
+
+// ScalingRequestResolved publishes a scaling request once it was approved, rejected or expired
+func (p *Publisher) ScalingRequestResolved(request *models.ScalingRequest) {
+	msg := "Scaling request " + string(request.Status)
+	event := models.NewEvent(models.EventTypeScalingRequestResolved, request.ClusterID, msg).
+		WithData(request)
+	p.publish(event)
+}
+
+// ServerHot alerts that one server runs far hotter than the rest of its cluster
+func (p *Publisher) ServerHot(clusterID string, server models.ServerAnalysis) {
+	msg := fmt.Sprintf("Server %s is hot: cpu %.1f%%, %.1f points above the cluster average",
+		server.ServerID, server.CPUUsage, server.CPUDeviation)
+	event := models.NewEvent(models.EventTypeServerHot, clusterID, msg).
+		WithSeverity(models.SeverityWarning).
+		WithData(server)
+	p.publish(event)
+}
+
+// ServerStale alerts that a server keeps reporting the same or all-zero metrics
+func (p *Publisher) ServerStale(clusterID string, server models.ServerAnalysis) {
+	msg := fmt.Sprintf("Server %s has reported unchanged metrics since %s",
+		server.ServerID, server.UnchangedSince.Format(time.RFC3339))
+	event := models.NewEvent(models.EventTypeServerStale, clusterID, msg).
+		WithSeverity(models.SeverityWarning).
+		WithData(server)
+	p.publish(event)
+}
+
+// LoadImbalance alerts that CPU is spread unevenly across a cluster's servers
+func (p *Publisher) LoadImbalance(report *models.ServerReport) {
+	msg := fmt.Sprintf("Load imbalance: cpu ranges %.1f%%-%.1f%% across servers (variation %.2f)",
+		report.MinCPU, report.MaxCPU, report.CPUVariation)
+	event := models.NewEvent(models.EventTypeLoadImbalance, report.ClusterID, msg).
+		WithSeverity(models.SeverityWarning).
+		WithData(report)
+	p.publish(event)
+}
+
+// DataQuality reports a cycle whose collected metrics failed validation
+func (p *Publisher) DataQuality(report *models.DataQualityReport) {
+	msg := fmt.Sprintf("Collected metrics failed %d data-quality check(s), applied %s",
+		len(report.Issues), report.Applied)
+	event := models.NewEvent(models.EventTypeDataQuality, report.ClusterID, msg).
+		WithSeverity(models.SeverityWarning).
+		WithData(report)
+	p.publish(event)
+}
+
 func (p *Publisher) Alert(clusterID string, severity models.EventSeverity, message string, data interface{}) {
 	event := models.NewEvent(models.EventTypeAlert, clusterID, message).
 		WithSeverity(severity).
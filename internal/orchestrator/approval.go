@@ -0,0 +1,176 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+var ErrRequestNotPending = errors.New("scaling request is not pending")
+
+// holdForApproval reports whether the decision has to wait for approval, opening a
+// scaling request for it unless one is already pending. Later decisions that need
+// approval wait on the same request. A request that can't be stored is dropped,
+// since nobody could approve it, and the error is returned with the hold so the
+// next cycle tries again. Callers must hold scaleMu.
+func (p *Pipeline) holdForApproval(ctx context.Context, scalingDecision *models.ScalingDecision) (bool, error) {
+	policy := p.config.Approval
+	if policy == nil || !policy.Requires(scalingDecision) {
+		return false, nil
+	}
+
+	p.requestMu.Lock()
+	defer p.requestMu.Unlock()
+
+	if p.request == nil {
+		request := models.NewScalingRequest(scalingDecision, policy)
+		if err := p.saveRequest(ctx, request); err != nil {
+			return true, fmt.Errorf("failed to store scaling request: %w", err)
+		}
+		p.request = request
+
+		logger.WithCluster(p.config.ClusterID).Warnf(
+			"Scale-up %d -> %d servers awaits approval (request %s, expires %s)",
+			request.CurrentServers, request.TargetServers, request.ID, request.ExpiresAt.Format(time.RFC3339),
+		)
+		created := *request
+		p.config.EventPublisher.ScalingRequestCreated(&created)
+	}
+
+	scalingDecision.ScalingRequestID = p.request.ID
+	return true, nil
+}
+
+// PendingRequest returns a copy of the scale-up waiting for approval, nil when there is none
+func (p *Pipeline) PendingRequest() *models.ScalingRequest {
+	p.requestMu.Lock()
+	defer p.requestMu.Unlock()
+
+	if p.request == nil {
+		return nil
+	}
+	request := *p.request
+	return &request
+}
+
+// Approve executes the pending scaling request on behalf of userID. Capacity that
+// arrived while it waited counts towards its target, so approving never scales down.
+func (p *Pipeline) Approve(ctx context.Context, requestID string, userID int) (*models.ScalingRequest, error) {
+	p.scaleMu.Lock()
+	defer p.scaleMu.Unlock()
+
+	request, err := p.takeRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Resolve(models.ScalingRequestApproved, &userID)
+	logger.WithCluster(p.config.ClusterID).Infof("Scaling request %s approved by user %d", request.ID, userID)
+	return request, p.executeRequest(ctx, request, "approved", &userID)
+}
+
+// Reject drops the pending scaling request on behalf of userID
+func (p *Pipeline) Reject(ctx context.Context, requestID string, userID int) (*models.ScalingRequest, error) {
+	request, err := p.takeRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Resolve(models.ScalingRequestRejected, &userID)
+	logger.WithCluster(p.config.ClusterID).Infof("Scaling request %s rejected by user %d", request.ID, userID)
+	p.resolve(ctx, request)
+	return request, nil
+}
+
+// expireRequest resolves a pending request nobody acted on in time, approving or
+// expiring it as the policy says. Callers must hold scaleMu.
+func (p *Pipeline) expireRequest(ctx context.Context) {
+	p.requestMu.Lock()
+	request := p.request
+	if request == nil || time.Now().Before(request.ExpiresAt) {
+		p.requestMu.Unlock()
+		return
+	}
+	p.request = nil
+	p.requestMu.Unlock()
+
+	clusterID := p.config.ClusterID
+	if p.config.Approval.OnTimeout == models.ApprovalTimeoutApprove {
+		request.Resolve(models.ScalingRequestApproved, nil)
+		logger.WithCluster(clusterID).Infof("Scaling request %s approved on timeout", request.ID)
+		if err := p.executeRequest(ctx, request, "auto_approved", nil); err != nil {
+			logger.WithCluster(clusterID).Errorf("Auto-approved scaling request %s failed: %v", request.ID, err)
+		}
+		return
+	}
+
+	request.Resolve(models.ScalingRequestExpired, nil)
+	logger.WithCluster(clusterID).Infof("Scaling request %s expired", request.ID)
+	p.resolve(ctx, request)
+}
+
+// takeRequest removes the pending request if it has the given ID
+func (p *Pipeline) takeRequest(requestID string) (*models.ScalingRequest, error) {
+	p.requestMu.Lock()
+	defer p.requestMu.Unlock()
+
+	if p.request == nil || p.request.ID != requestID {
+		return nil, ErrRequestNotPending
+	}
+	request := p.request
+	p.request = nil
+	return request, nil
+}
+
+// executeRequest scales towards an approved request's target, unless a freeze or
+// observe mode now stands in the way, and stores the request with its outcome.
+// Callers must hold scaleMu.
+func (p *Pipeline) executeRequest(ctx context.Context, request *models.ScalingRequest, reason string, userID *int) error {
+	defer p.resolve(ctx, request)
+
+	state, err := p.config.Scaler.GetClusterState(ctx, p.config.ClusterID)
+	if err != nil {
+		request.Outcome = models.OutcomeFailed
+		request.Error = err.Error()
+		return err
+	}
+
+	target := max(request.TargetServers, state.CommittedServers())
+	scalingDecision := newManualDecision(p.config.ClusterID, state, target, reason, userID)
+	scalingDecision.ScalingRequestID = request.ID
+
+	// A freeze may have opened, or the cluster switched to observe mode, while the
+	// request waited; approving doesn't get past either
+	frozen := scalingDecision.ShouldExecute() && p.frozen(ctx, scalingDecision)
+	observe := scalingDecision.ShouldExecute() && !frozen && p.observeOnly.Load()
+	if frozen || observe {
+		p.publishDecision(scalingDecision)
+		outcome := models.OutcomeFrozen
+		if observe {
+			outcome = p.observe(scalingDecision)
+		}
+		p.recordOutcome(ctx, scalingDecision, outcome, nil)
+		request.Outcome = outcome
+		return nil
+	}
+
+	record, err := p.carryOut(ctx, scalingDecision)
+	request.Outcome = record.Outcome
+	request.Error = record.Error
+	return err
+}
+
+// resolve stores a resolved request and publishes a copy, so subscribers never
+// share the pipeline's request
+func (p *Pipeline) resolve(ctx context.Context, request *models.ScalingRequest) {
+	if err := p.saveRequest(ctx, request); err != nil {
+		logger.WithCluster(p.config.ClusterID).Errorf("Failed to persist scaling request %s: %v", request.ID, err)
+	}
+
+	resolved := *request
+	p.config.EventPublisher.ScalingRequestResolved(&resolved)
+}
@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/analyzer"
+	"github.com/OldStager01/cloud-autoscaler/internal/decision"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// pipelineSpec is everything a cluster's pipeline is built from, so a cluster
+// update only needs a new pipeline when its spec changed. Observe mode isn't part
+// of it since a running pipeline switches mode in place.
+type pipelineSpec struct {
+	analyzer        analyzer.Config
+	decision        decision.Config
+	collectInterval time.Duration
+	approval        *models.ApprovalPolicy
+	endpoint        string
+}
+
+func (o *Orchestrator) pipelineSpec(cluster *models.Cluster) pipelineSpec {
+	analyzerCfg, decisionCfg, interval := o.clusterConfigs(cluster)
+	return pipelineSpec{
+		analyzer:        analyzerCfg,
+		decision:        decisionCfg,
+		collectInterval: interval,
+		approval:        approvalPolicy(cluster),
+		endpoint:        cluster.MetricsEndpoint(""),
+	}
+}
+
+func (s pipelineSpec) equal(other pipelineSpec) bool {
+	return reflect.DeepEqual(s, other)
+}
+
+// clusterConfigs merges a cluster's limits and overrides over the global analyzer
+// and decision settings, and returns the cluster's collect interval
+func (o *Orchestrator) clusterConfigs(cluster *models.Cluster) (analyzer.Config, decision.Config, time.Duration) {
+	analyzerCfg := o.analyzerConfig
+
+	decisionCfg := o.decisionConfig
+	decisionCfg.MinServers = cluster.MinServers
+	decisionCfg.MaxServers = cluster.MaxServers
+
+	interval := o.config.Collector.Interval
+
+	c := cluster.Config
+	if c == nil {
+		return analyzerCfg, decisionCfg, interval
+	}
+
+	if c.TargetCPU > 0 {
+		decisionCfg.TargetCPU = c.TargetCPU
+	}
+	if c.TargetMemory > 0 {
+		decisionCfg.TargetMemory = c.TargetMemory
+	}
+	decisionCfg.ScalingMode = c.ScalingMode
+	decisionCfg.ScalingSignals = c.ScalingSignals
+	decisionCfg.TargetLoadPerServer = c.TargetLoadPerServer
+	decisionCfg.CustomSignals = c.CustomSignals
+	decisionCfg.Policy = c.Policy
+
+	// Thresholds drive both the analyzer's status and the engine's decisions
+	overrideFloat(&analyzerCfg.CPUHighThreshold, c.CPUHighThreshold)
+	overrideFloat(&analyzerCfg.CPULowThreshold, c.CPULowThreshold)
+	overrideFloat(&analyzerCfg.MemoryHighThreshold, c.MemoryHighThreshold)
+	overrideFloat(&analyzerCfg.MemoryLowThreshold, c.MemoryLowThreshold)
+	overrideFloat(&analyzerCfg.SpikeThreshold, c.SpikeThreshold)
+	overrideSeconds(&analyzerCfg.TrendWindow, c.TrendWindowSeconds)
+	overrideSeconds(&analyzerCfg.PercentileWindow, c.PercentileWindowSeconds)
+	if c.CPUStatistic != nil {
+		analyzerCfg.CPUStatistic = models.CPUStatistic(*c.CPUStatistic)
+	}
+
+	overrideFloat(&decisionCfg.CPUHighThreshold, c.CPUHighThreshold)
+	overrideFloat(&decisionCfg.CPULowThreshold, c.CPULowThreshold)
+	overrideFloat(&decisionCfg.MemoryHighThreshold, c.MemoryHighThreshold)
+	overrideFloat(&decisionCfg.EmergencyCPUThreshold, c.EmergencyCPUThreshold)
+	overrideSeconds(&decisionCfg.CooldownPeriod, c.CooldownSeconds)
+	overrideSeconds(&decisionCfg.ScaleDownCooldownPeriod, c.ScaleDownCooldownSeconds)
+	overrideSeconds(&decisionCfg.SustainedHighDuration, c.SustainedHighSeconds)
+	overrideSeconds(&decisionCfg.SustainedLowDuration, c.SustainedLowSeconds)
+	overrideInt(&decisionCfg.MaxScaleStep, c.MaxScaleStep)
+	overrideInt(&decisionCfg.MaxScaleDownStep, c.MaxScaleDownStep)
+	overrideFloat(&decisionCfg.MaxScaleDownPercent, c.MaxScaleDownPercent)
+	overrideFloat(&decisionCfg.ScaleDownSafetyMargin, c.ScaleDownSafetyMargin)
+	overrideFloat(&decisionCfg.PredictionMinConfidence, c.PredictionMinConfidence)
+	if c.ScaleDownMode != nil {
+		decisionCfg.ScaleDownMode = *c.ScaleDownMode
+	}
+
+	overrideSeconds(&interval, c.CollectIntervalSeconds)
+
+	return analyzerCfg, decisionCfg, interval
+}
+
+func overrideFloat(dst *float64, v *float64) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func overrideInt(dst *int, v *int) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func overrideSeconds(dst *time.Duration, v *int) {
+	if v != nil {
+		*dst = time.Duration(*v) * time.Second
+	}
+}
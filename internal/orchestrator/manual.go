@@ -0,0 +1,141 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// ScaleTo scales the cluster to target servers once, on behalf of userID. The
+// cooldowns start as for an automatic action, so the engine doesn't undo it straight away.
+func (p *Pipeline) ScaleTo(ctx context.Context, target, userID int) (*models.DecisionRecord, error) {
+	p.scaleMu.Lock()
+	defer p.scaleMu.Unlock()
+
+	clusterID := p.config.ClusterID
+	state, err := p.config.Scaler.GetClusterState(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.carryOut(ctx, newManualDecision(clusterID, state, target, "manual_scale", &userID))
+}
+
+// carryOut publishes and executes a decision made outside the run loop.
+// Callers must hold scaleMu.
+func (p *Pipeline) carryOut(ctx context.Context, scalingDecision *models.ScalingDecision) (*models.DecisionRecord, error) {
+	clusterID := p.config.ClusterID
+	p.publishDecision(scalingDecision)
+
+	outcome := models.OutcomeMaintained
+	var execErr error
+	if scalingDecision.ShouldExecute() {
+		outcome, execErr = p.execute(ctx, scalingDecision)
+		p.metrics.IncScalingEvent(clusterID, string(scalingDecision.Action))
+	}
+
+	return p.recordOutcome(ctx, scalingDecision, outcome, execErr), execErr
+}
+
+// SetPin holds the cluster at the pin's size until it expires, replacing the
+// automatic decisions. The next cycle scales towards it.
+func (p *Pipeline) SetPin(pin models.ClusterPin) {
+	p.pinMu.Lock()
+	defer p.pinMu.Unlock()
+
+	p.pin = &pin
+	logger.WithCluster(p.config.ClusterID).Infof(
+		"Cluster pinned to %d servers until %s by user %d",
+		pin.DesiredServers, pin.Until.Format(time.RFC3339), pin.PinnedBy,
+	)
+}
+
+// ClearPin releases the pin and reports whether there was one
+func (p *Pipeline) ClearPin() bool {
+	p.pinMu.Lock()
+	defer p.pinMu.Unlock()
+
+	if p.pin == nil {
+		return false
+	}
+	p.pin = nil
+	logger.WithCluster(p.config.ClusterID).Info("Pin released, automatic scaling resumed")
+	return true
+}
+
+// Pin returns a copy of the active pin, or nil when the cluster isn't pinned
+func (p *Pipeline) Pin() *models.ClusterPin {
+	p.pinMu.Lock()
+	defer p.pinMu.Unlock()
+
+	if p.pin == nil || !p.pin.IsActive(time.Now()) {
+		return nil
+	}
+	pin := *p.pin
+	return &pin
+}
+
+// activePin is Pin for the run loop: it also drops an expired pin and announces
+// that automatic scaling has resumed
+func (p *Pipeline) activePin() *models.ClusterPin {
+	p.pinMu.Lock()
+	defer p.pinMu.Unlock()
+
+	if p.pin == nil {
+		return nil
+	}
+	if !p.pin.IsActive(time.Now()) {
+		expired := *p.pin
+		p.pin = nil
+
+		clusterID := p.config.ClusterID
+		logger.WithCluster(clusterID).Info("Pin expired, automatic scaling resumed")
+		p.config.EventPublisher.Alert(clusterID, models.SeverityInfo,
+			"Pin expired, automatic scaling resumed", expired)
+		return nil
+	}
+
+	pin := *p.pin
+	return &pin
+}
+
+// newManualDecision builds the decision that moves the cluster to target servers
+// on behalf of userID, if any. Like the engine, it counts servers still
+// provisioning as current capacity.
+func newManualDecision(clusterID string, state *models.ClusterState, target int, reason string, userID *int) *models.ScalingDecision {
+	current := state.CommittedServers()
+	scalingDecision := &models.ScalingDecision{
+		ClusterID:           clusterID,
+		Timestamp:           time.Now(),
+		Action:              models.ActionMaintain,
+		CurrentServers:      current,
+		TargetServers:       current,
+		ProvisioningServers: state.ProvisioningCnt,
+		DrainingServers:     state.DrainingCount,
+		Reason:              reason,
+		InitiatedBy:         userID,
+	}
+
+	switch {
+	case target > current:
+		scalingDecision.Action = models.ActionScaleUp
+	case target < current:
+		scalingDecision.Action = models.ActionScaleDown
+	default:
+		return scalingDecision
+	}
+	scalingDecision.TargetServers = target
+
+	if userID == nil {
+		logger.WithCluster(clusterID).Infof(
+			"Decision: %s %d -> %d servers (reason: %s)", scalingDecision.Action, current, target, reason,
+		)
+	} else {
+		logger.WithCluster(clusterID).Infof(
+			"Decision: %s %d -> %d servers (reason: %s, user: %d)", scalingDecision.Action, current, target, reason, *userID,
+		)
+	}
+	return scalingDecision
+}
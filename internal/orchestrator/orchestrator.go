@@ -3,6 +3,7 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -11,26 +12,34 @@ import (
 	"github.com/OldStager01/cloud-autoscaler/internal/decision"
 	"github.com/OldStager01/cloud-autoscaler/internal/events"
 	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/internal/predictor"
 	"github.com/OldStager01/cloud-autoscaler/internal/resilience"
 	"github.com/OldStager01/cloud-autoscaler/internal/scaler"
+	"github.com/OldStager01/cloud-autoscaler/internal/schedule"
 	"github.com/OldStager01/cloud-autoscaler/pkg/config"
 	"github.com/OldStager01/cloud-autoscaler/pkg/database"
+	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
 	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 )
 
 type Orchestrator struct {
-	config         *config.Config
-	db             *database.DB
-	eventBus       *events.EventBus
-	eventLogger    *events.EventLogger
-	pipelines      map[string]*Pipeline
-	mu             sync.RWMutex
-	ctx            context.Context
-	cancel         context.CancelFunc
-	wg             sync.WaitGroup
-	analyzerConfig analyzer.Config
-	decisionConfig decision.Config
-	started        bool
+	config          *config.Config
+	db              *database.DB
+	eventBus        *events.EventBus
+	eventLogger     *events.EventLogger
+	aggregator      *predictor.PatternAggregator
+	accuracy        *predictor.AccuracyTracker
+	scheduler       *schedule.Scheduler
+	freezes         *schedule.FreezeChecker
+	pipelines       map[string]*Pipeline
+	mu              sync.RWMutex
+	ctx             context.Context
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup
+	analyzerConfig  analyzer.Config
+	decisionConfig  decision.Config
+	predictorConfig predictor.Config
+	started         bool
 }
 
 func New(cfg *config.Config, db *database.DB) *Orchestrator {
@@ -56,9 +65,27 @@ func New(cfg *config.Config, db *database.DB) *Orchestrator {
 		CPUHighThreshold:    cfg.Analyzer.Thresholds.CPUHigh,
 		CPULowThreshold:     cfg.Analyzer.Thresholds.CPULow,
 		MemoryHighThreshold: cfg.Analyzer.Thresholds.MemoryHigh,
+		MemoryLowThreshold:  cfg.Analyzer.Thresholds.MemoryLow,
 		TrendWindow:         cfg.Analyzer.TrendWindow,
 		SpikeThreshold:      cfg.Analyzer.SpikeThreshold,
 		MaxHistoryLength:    maxHistoryLen,
+		CPUStatistic:        models.CPUStatistic(cfg.Analyzer.CPUStatistic),
+		PercentileWindow:    cfg.Analyzer.PercentileWindow,
+		Anomaly: analyzer.AnomalyConfig{
+			Detector:        cfg.Analyzer.Anomaly.Detector,
+			BaselineSamples: cfg.Analyzer.Anomaly.BaselineSamples,
+			MinSamples:      cfg.Analyzer.Anomaly.MinSamples,
+			Sensitivity:     cfg.Analyzer.Anomaly.Sensitivity,
+			MinDeviation:    cfg.Analyzer.Anomaly.MinDeviation,
+			EWMAAlpha:       cfg.Analyzer.Anomaly.EWMAAlpha,
+		},
+		Servers: analyzer.ServerConfig{
+			HotCPUThreshold:    cfg.Analyzer.Servers.HotCPUThreshold,
+			HotCPUMargin:       cfg.Analyzer.Servers.HotCPUMargin,
+			ImbalanceThreshold: cfg.Analyzer.Servers.ImbalanceThreshold,
+			ImbalanceMinCPU:    cfg.Analyzer.Servers.ImbalanceMinCPU,
+			StaleAfter:         cfg.Analyzer.Servers.StaleAfter,
+		},
 	}
 
 	decisionCfg := decision.Config{
@@ -70,21 +97,85 @@ func New(cfg *config.Config, db *database.DB) *Orchestrator {
 		MinServers:              cfg.Decision.MinServers,
 		MaxServers:              cfg.Decision.MaxServers,
 		MaxScaleStep:            cfg.Decision.MaxScaleStep,
+		ScaleDownMode:           cfg.Decision.ScaleDownMode,
+		MaxScaleDownStep:        cfg.Decision.MaxScaleDownStep,
+		MaxScaleDownPercent:     cfg.Decision.MaxScaleDownPercent,
+		ScaleDownSafetyMargin:   cfg.Decision.ScaleDownSafetyMargin,
+		Flap: decision.FlapConfig{
+			Enabled:            cfg.Decision.FlapDamping.Enabled,
+			Window:             cfg.Decision.FlapDamping.Window,
+			Reversals:          cfg.Decision.FlapDamping.Reversals,
+			CooldownMultiplier: cfg.Decision.FlapDamping.CooldownMultiplier,
+			HysteresisMargin:   cfg.Decision.FlapDamping.HysteresisMargin,
+			StableFor:          cfg.Decision.FlapDamping.StableFor,
+		},
 		CPUHighThreshold:        cfg.Analyzer.Thresholds.CPUHigh,
 		CPULowThreshold:         cfg.Analyzer.Thresholds.CPULow,
+		MemoryHighThreshold:     cfg.Analyzer.Thresholds.MemoryHigh,
+		PredictionMinConfidence: cfg.Predictor.MinConfidence,
+	}
+
+	predictorCfg := predictor.Config{
+		Types:          cfg.Predictor.Type,
+		ForecastWindow: cfg.Predictor.ForecastWindow,
+		Smoothing: predictor.SmoothingConfig{
+			Alpha:        cfg.Predictor.Smoothing.Alpha,
+			Beta:         cfg.Predictor.Smoothing.Beta,
+			Gamma:        cfg.Predictor.Smoothing.Gamma,
+			Damping:      cfg.Predictor.Smoothing.Damping,
+			SeasonLength: cfg.Predictor.Smoothing.SeasonLength,
+		},
 	}
 
-	return &Orchestrator{
-		config:         cfg,
+	// Pattern matching reads weekly buckets that a background aggregator keeps fresh
+	var aggregator *predictor.PatternAggregator
+	if cfg.Predictor.Enabled && slices.Contains(cfg.Predictor.Type, predictor.TypePatternMatching) {
+		patternRepo := queries.NewPatternRepository(db.DB)
+		predictorCfg.Patterns = patternRepo
+		aggregator = predictor.NewPatternAggregator(predictor.AggregatorConfig{
+			Patterns: patternRepo,
+			Interval: cfg.Predictor.AggregationInterval,
+			Lookback: cfg.Predictor.PatternLookback,
+		})
+	}
+
+	// Persisted forecasts are scored once the observed CPU is known
+	var accuracy *predictor.AccuracyTracker
+	if cfg.Predictor.Enabled {
+		accuracy = predictor.NewAccuracyTracker(predictor.BackfillConfig{
+			Predictions: queries.NewPredictionRepository(db.DB),
+			Interval:    cfg.Predictor.BackfillInterval,
+			Window:      cfg.Predictor.BackfillWindow,
+		})
+	}
+
+	o := &Orchestrator{
+		config:          cfg,
 		db:              db,
-		eventBus:       eventBus,
-		eventLogger:    eventLogger,
-		pipelines:      make(map[string]*Pipeline),
-		ctx:            ctx,
+		eventBus:        eventBus,
+		eventLogger:     eventLogger,
+		aggregator:      aggregator,
+		accuracy:        accuracy,
+		freezes:         schedule.NewFreezeChecker(queries.NewFreezeRepository(db.DB)),
+		pipelines:       make(map[string]*Pipeline),
+		ctx:             ctx,
 		cancel:          cancel,
-		analyzerConfig: analyzerCfg,
-		decisionConfig: decisionCfg,
+		analyzerConfig:  analyzerCfg,
+		decisionConfig:  decisionCfg,
+		predictorConfig: predictorCfg,
+	}
+
+	// Scaling schedules override the limits of running pipelines while their window is open
+	if cfg.Scheduler.Enabled {
+		o.scheduler = schedule.NewScheduler(schedule.Config{
+			Schedules: queries.NewScheduleRepository(db.DB),
+			Target:    o,
+			Publisher: events.NewPublisher(eventBus),
+			Interval:  cfg.Scheduler.Interval,
+		})
 	}
+
+	return o
 }
 
 func (o *Orchestrator) Start() error {
@@ -97,6 +188,15 @@ func (o *Orchestrator) Start() error {
 
 	logger.Info("Orchestrator starting")
 	o.eventLogger.Start()
+	if o.aggregator != nil {
+		o.aggregator.Start()
+	}
+	if o.accuracy != nil {
+		o.accuracy.Start()
+	}
+	if o.scheduler != nil {
+		o.scheduler.Start()
+	}
 	o.started = true
 
 	return nil
@@ -146,6 +246,17 @@ func (o *Orchestrator) Stop(ctx context.Context) error {
 	// Wait for orchestrator goroutines
 	o.wg.Wait()
 
+	// Stop background jobs
+	if o.aggregator != nil {
+		o.aggregator.Stop()
+	}
+	if o.accuracy != nil {
+		o.accuracy.Stop()
+	}
+	if o.scheduler != nil {
+		o.scheduler.Stop()
+	}
+
 	// Stop event logger
 	o.eventLogger.Stop()
 
@@ -164,6 +275,62 @@ func (o *Orchestrator) StartCluster(cluster *models.Cluster, coll collector.Coll
 		return fmt.Errorf("pipeline already exists for cluster %s", cluster.ID)
 	}
 
+	return o.startCluster(cluster, coll, scal)
+}
+
+// RestartCluster replaces a running cluster's pipeline so changes to its config
+// take effect. The scaler carries over; the collector is replaced since the
+// metrics endpoint may have changed. A pipeline whose spec didn't change keeps
+// running, along with its cooldowns, damping and pending request, and only picks
+// up the cluster's mode. It returns whether the pipeline was replaced.
+func (o *Orchestrator) RestartCluster(cluster *models.Cluster, coll collector.Collector) (bool, error) {
+	if err := o.ValidateCluster(cluster); err != nil {
+		return false, fmt.Errorf("cluster %s: %w", cluster.ID, err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	old, exists := o.pipelines[cluster.ID]
+	if !exists {
+		return false, nil
+	}
+	if old.spec.equal(o.pipelineSpec(cluster)) {
+		old.SetObserveOnly(cluster.IsObserveOnly())
+		return false, nil
+	}
+
+	old.Stop()
+	delete(o.pipelines, cluster.ID)
+	logger.WithCluster(cluster.ID).Info("Restarting cluster pipeline for updated config")
+
+	if err := o.startCluster(cluster, coll, old.config.Scaler); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ValidateCluster checks a cluster's overrides still hold once merged with the
+// global config, e.g. that a lone cpu_low_threshold stays below the global high one
+func (o *Orchestrator) ValidateCluster(cluster *models.Cluster) error {
+	analyzerCfg, _, _ := o.clusterConfigs(cluster)
+	if analyzerCfg.CPUHighThreshold <= analyzerCfg.CPULowThreshold {
+		return fmt.Errorf("cpu_high_threshold (%v) must be greater than cpu_low_threshold (%v) once merged with the global config",
+			analyzerCfg.CPUHighThreshold, analyzerCfg.CPULowThreshold)
+	}
+	if analyzerCfg.MemoryHighThreshold <= analyzerCfg.MemoryLowThreshold {
+		return fmt.Errorf("memory_high_threshold (%v) must be greater than memory_low_threshold (%v) once merged with the global config",
+			analyzerCfg.MemoryHighThreshold, analyzerCfg.MemoryLowThreshold)
+	}
+	return nil
+}
+
+// startCluster builds and starts the cluster's pipeline. Callers must hold mu.
+func (o *Orchestrator) startCluster(cluster *models.Cluster, coll collector.Collector, scal scaler.Scaler) error {
+	if err := o.ValidateCluster(cluster); err != nil {
+		return fmt.Errorf("cluster %s: %w", cluster.ID, err)
+	}
+
 	// Wrap collector with resilience
 	resilientColl := collector.NewResilientCollector(collector.ResilientCollectorConfig{
 		Collector:     coll,
@@ -185,33 +352,55 @@ func (o *Orchestrator) StartCluster(cluster *models.Cluster, coll collector.Coll
 		},
 	})
 
-	// Create cluster-specific decision config using the cluster's min/max server limits
-	clusterDecisionConfig := decision.Config{
-		CooldownPeriod:          o.decisionConfig.CooldownPeriod,
-		ScaleDownCooldownPeriod: o.decisionConfig.ScaleDownCooldownPeriod,
-		SustainedHighDuration:   o.decisionConfig.SustainedHighDuration,
-		SustainedLowDuration:    o.decisionConfig.SustainedLowDuration,
-		EmergencyCPUThreshold:   o.decisionConfig.EmergencyCPUThreshold,
-		MinServers:              cluster.MinServers,
-		MaxServers:              cluster.MaxServers,
-		MaxScaleStep:            o.decisionConfig.MaxScaleStep,
-		TargetCPU:               o.decisionConfig.TargetCPU,
-		CPUHighThreshold:        o.decisionConfig.CPUHighThreshold,
-		CPULowThreshold:         o.decisionConfig.CPULowThreshold,
+	analyzerCfg, clusterDecisionConfig, collectInterval := o.clusterConfigs(cluster)
+
+	// Each cluster gets its own predictor so model state is never shared
+	var clusterPredictor predictor.Predictor
+	if o.config.Predictor.Enabled {
+		p, err := predictor.New(o.predictorConfig)
+		if err != nil {
+			logger.WithCluster(cluster.ID).Warnf("Predictor disabled: %v", err)
+		} else {
+			clusterPredictor = p
+		}
+	}
+
+	// Requests left pending by an earlier pipeline can no longer be approved
+	if err := queries.NewScalingRequestRepository(o.db.DB).ExpirePending(o.ctx, cluster.ID); err != nil {
+		logger.WithCluster(cluster.ID).Warnf("Failed to expire stale scaling requests: %v", err)
 	}
 
 	pipeline := NewPipeline(PipelineConfig{
 		ClusterID:         cluster.ID,
-		CollectInterval:  o.config.Collector.Interval,
-		Collector:        resilientColl,
-		Analyzer:         analyzer.New(o.analyzerConfig),
-		SustainedTracker: analyzer.NewSustainedTracker(),
-		DecisionEngine:   decision.NewEngine(clusterDecisionConfig),
-		Scaler:           scal,
-		EventPublisher:   events.NewPublisher(o.eventBus),
-		AnalyzerConfig:   o.analyzerConfig,
+		CollectInterval:   collectInterval,
+		Collector:         resilientColl,
+		Analyzer:          analyzer.New(analyzerCfg),
+		SustainedTracker:  analyzer.NewSustainedTracker(),
+		ServerAnalyzer:    analyzer.NewServerAnalyzer(analyzerCfg.Servers),
+		DecisionEngine:    decision.NewEngine(clusterDecisionConfig),
+		Predictor:         clusterPredictor,
+		ShadowPredictions: o.config.Predictor.Shadow,
+		Scaler:            scal,
+		EventPublisher:    events.NewPublisher(o.eventBus),
+		AnalyzerConfig:    analyzerCfg,
+		ObserveOnly:       cluster.IsObserveOnly(),
+		Freezes:           o.freezes,
+		Approval:          approvalPolicy(cluster),
+		History:           queries.NewMetricsRepository(o.db.DB),
+		DataQuality:       o.qualityChecker(),
+		Decisions:         queries.NewDecisionRepository(o.db.DB),
+		Requests:          queries.NewScalingRequestRepository(o.db.DB),
 	})
 
+	pipeline.spec = o.pipelineSpec(cluster)
+
+	// Pins outlive the pipeline that applied them
+	if pin, err := queries.NewPinRepository(o.db.DB).GetActive(o.ctx, cluster.ID); err != nil {
+		logger.WithCluster(cluster.ID).Warnf("Failed to restore pin: %v", err)
+	} else if pin != nil {
+		pipeline.SetPin(*pin)
+	}
+
 	if err := pipeline.Start(); err != nil {
 		return fmt.Errorf("failed to start pipeline:  %w", err)
 	}
@@ -238,6 +427,30 @@ func (o *Orchestrator) StopCluster(clusterID string) error {
 	return nil
 }
 
+// ApplyOverride installs a limits override on the cluster's decision engine.
+// It returns false when the cluster has no running pipeline.
+func (o *Orchestrator) ApplyOverride(clusterID string, override decision.Override) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return false
+	}
+
+	pipeline.config.DecisionEngine.ApplyOverride(override)
+	return true
+}
+
+func (o *Orchestrator) ClearOverride(clusterID, overrideID string) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if pipeline, exists := o.pipelines[clusterID]; exists {
+		pipeline.config.DecisionEngine.ClearOverride(overrideID)
+	}
+}
+
 func (o *Orchestrator) StartAllClusters(clusters []*models.Cluster, collectorFactory func(string) collector.Collector, scalerFactory func(string) scaler.Scaler) error {
 	var wg sync.WaitGroup
 	errChan := make(chan error, len(clusters))
@@ -285,6 +498,173 @@ func (o *Orchestrator) GetClusterStatus(clusterID string) (bool, error) {
 	return pipeline.IsRunning(), nil
 }
 
+// RecentDecisions returns the cluster's latest decisions, newest first, including their traces
+func (o *Orchestrator) RecentDecisions(clusterID string, limit int) ([]*models.ScalingDecision, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return nil, fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	return pipeline.RecentDecisions(limit), nil
+}
+
+// ClusterServers returns a running cluster's latest per-server report
+func (o *Orchestrator) ClusterServers(clusterID string) (*models.ServerReport, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return nil, fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	return pipeline.Servers(), nil
+}
+
+// SetObserveOnly switches a running cluster between observe and managed mode
+func (o *Orchestrator) SetObserveOnly(clusterID string, observe bool) error {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	pipeline.SetObserveOnly(observe)
+	return nil
+}
+
+// ScaleCluster scales a running cluster to target servers once, attributed to userID
+func (o *Orchestrator) ScaleCluster(ctx context.Context, clusterID string, target, userID int) (*models.DecisionRecord, error) {
+	o.mu.RLock()
+	pipeline, exists := o.pipelines[clusterID]
+	o.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	return pipeline.ScaleTo(ctx, target, userID)
+}
+
+// PinCluster holds a running cluster at the pin's size until it expires
+func (o *Orchestrator) PinCluster(clusterID string, pin models.ClusterPin) error {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	pipeline.SetPin(pin)
+	return nil
+}
+
+// UnpinCluster releases a cluster's pin. It returns false when the cluster wasn't pinned.
+func (o *Orchestrator) UnpinCluster(clusterID string) (bool, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return false, fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	return pipeline.ClearPin(), nil
+}
+
+// ClusterPin returns a running cluster's active pin, nil when it isn't pinned
+func (o *Orchestrator) ClusterPin(clusterID string) (*models.ClusterPin, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return nil, fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	return pipeline.Pin(), nil
+}
+
+// PendingScalingRequest returns the scale-up a running cluster holds for approval, nil when there is none
+func (o *Orchestrator) PendingScalingRequest(clusterID string) (*models.ScalingRequest, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return nil, fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	return pipeline.PendingRequest(), nil
+}
+
+// ApproveScalingRequest executes a running cluster's pending scale-up, attributed to userID
+func (o *Orchestrator) ApproveScalingRequest(ctx context.Context, clusterID, requestID string, userID int) (*models.ScalingRequest, error) {
+	o.mu.RLock()
+	pipeline, exists := o.pipelines[clusterID]
+	o.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrRequestNotPending
+	}
+
+	return pipeline.Approve(ctx, requestID, userID)
+}
+
+// RejectScalingRequest drops a running cluster's pending scale-up, attributed to userID
+func (o *Orchestrator) RejectScalingRequest(ctx context.Context, clusterID, requestID string, userID int) (*models.ScalingRequest, error) {
+	o.mu.RLock()
+	pipeline, exists := o.pipelines[clusterID]
+	o.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrRequestNotPending
+	}
+
+	return pipeline.Reject(ctx, requestID, userID)
+}
+
+// qualityChecker builds a pipeline's data-quality checker, nil when checks are disabled
+func (o *Orchestrator) qualityChecker() *collector.QualityChecker {
+	cfg := o.config.Collector.Quality
+	if !cfg.Enabled {
+		return nil
+	}
+	return collector.NewQualityChecker(collector.QualityConfig{
+		Policy:               models.DataQualityPolicy(cfg.Policy),
+		StaleIntervals:       cfg.StaleIntervals,
+		CheckServerCount:     cfg.CheckServerCount,
+		ServerCountTolerance: cfg.ServerCountTolerance,
+	})
+}
+
+// approvalPolicy returns the cluster's approval policy, nil when it has none
+func approvalPolicy(cluster *models.Cluster) *models.ApprovalPolicy {
+	if cluster.Config == nil {
+		return nil
+	}
+	return cluster.Config.Approval
+}
+
+// Damping returns the flap damping state of a running cluster
+func (o *Orchestrator) Damping(clusterID string) (models.DampingState, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	pipeline, exists := o.pipelines[clusterID]
+	if !exists {
+		return models.DampingState{}, fmt.Errorf("no pipeline found for cluster %s", clusterID)
+	}
+
+	return pipeline.config.DecisionEngine.Damping(clusterID), nil
+}
+
 func (o *Orchestrator) ListRunningClusters() []string {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -316,4 +696,4 @@ func (o *Orchestrator) WaitForShutdown(timeout time.Duration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	return o.Stop(ctx)
-}
\ No newline at end of file
+}
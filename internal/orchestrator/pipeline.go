@@ -2,7 +2,9 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/OldStager01/cloud-autoscaler/internal/analyzer"
@@ -11,30 +13,81 @@ import (
 	"github.com/OldStager01/cloud-autoscaler/internal/events"
 	"github.com/OldStager01/cloud-autoscaler/internal/logger"
 	"github.com/OldStager01/cloud-autoscaler/internal/metrics"
+	"github.com/OldStager01/cloud-autoscaler/internal/predictor"
 	"github.com/OldStager01/cloud-autoscaler/internal/scaler"
 	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 )
 
 type PipelineConfig struct {
-	ClusterID        string
-	CollectInterval  time.Duration
-	Collector        collector.Collector
-	Analyzer         *analyzer.Analyzer
-	SustainedTracker *analyzer.SustainedTracker
-	DecisionEngine   *decision.Engine
-	Scaler           scaler.Scaler
-	EventPublisher   *events.Publisher
-	AnalyzerConfig   analyzer.Config
+	ClusterID         string
+	CollectInterval   time.Duration
+	Collector         collector.Collector
+	Analyzer          *analyzer.Analyzer
+	SustainedTracker  *analyzer.SustainedTracker
+	ServerAnalyzer    *analyzer.ServerAnalyzer // nil skips per-server analysis
+	DecisionEngine    *decision.Engine
+	Predictor         predictor.Predictor
+	ShadowPredictions bool
+	Scaler            scaler.Scaler
+	EventPublisher    *events.Publisher
+	AnalyzerConfig    analyzer.Config
+	ObserveOnly       bool
+	Freezes           FreezeChecker             // nil when freeze windows aren't checked
+	Approval          *models.ApprovalPolicy    // nil when scale-ups never wait for approval
+	History           HistorySource             // nil starts with empty analyzer history
+	DataQuality       *collector.QualityChecker // nil skips data-quality checks
+	Decisions         DecisionStore             // nil leaves decision records unstored
+	Requests          RequestStore              // nil leaves scaling requests unstored
 }
 
+// FreezeChecker lists the freeze windows open for a cluster
+type FreezeChecker interface {
+	ActiveFreezes(ctx context.Context, clusterID string, now time.Time) ([]models.ActiveFreeze, error)
+}
+
+// Number of recent decisions each pipeline keeps for the decisions endpoint
+const maxRecentDecisions = 100
+
 type Pipeline struct {
-	config   PipelineConfig
-	ctx      context.Context
-	cancel   context.CancelFunc
-	wg       sync.WaitGroup
-	running  bool
-	mu       sync.Mutex
-	metrics  *metrics.Metrics
+	config  PipelineConfig
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+	mu      sync.Mutex
+	metrics *metrics.Metrics
+
+	decisionsMu sync.RWMutex
+	decisions   []*models.ScalingDecision
+
+	// damped is only touched by the run loop
+	damped bool
+
+	// lastGood is the latest analysis of metrics that passed data-quality checks;
+	// only touched by the run loop
+	lastGood *models.AnalyzedMetrics
+
+	// observeOnly stops the pipeline from calling the scaler; it can change while running
+	observeOnly atomic.Bool
+
+	// pin holds the cluster at an operator-chosen size; pinMu guards it
+	pin   *models.ClusterPin
+	pinMu sync.Mutex
+
+	// scaleMu serialises reading cluster state and scaling between the run loop
+	// and manual scale requests
+	scaleMu sync.Mutex
+
+	// request is the scale-up waiting for approval; requestMu guards it
+	request   *models.ScalingRequest
+	requestMu sync.Mutex
+
+	// servers is the latest per-server report; serversMu guards it
+	servers   *models.ServerReport
+	serversMu sync.RWMutex
+
+	// spec is the cluster config the orchestrator built the pipeline from
+	spec pipelineSpec
 }
 
 func NewPipeline(cfg PipelineConfig) *Pipeline {
@@ -44,12 +97,25 @@ func NewPipeline(cfg PipelineConfig) *Pipeline {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &Pipeline{
+	p := &Pipeline{
 		config:  cfg,
 		ctx:     ctx,
 		cancel:  cancel,
 		metrics: metrics.Get(),
 	}
+	p.observeOnly.Store(cfg.ObserveOnly)
+	return p
+}
+
+// SetObserveOnly switches the pipeline between observing and managing the cluster
+func (p *Pipeline) SetObserveOnly(observe bool) {
+	if p.observeOnly.Swap(observe) != observe {
+		mode := models.ClusterModeManaged
+		if observe {
+			mode = models.ClusterModeObserve
+		}
+		logger.WithCluster(p.config.ClusterID).Infof("Pipeline switched to %s mode", mode)
+	}
 }
 
 func (p *Pipeline) Start() error {
@@ -60,6 +126,8 @@ func (p *Pipeline) Start() error {
 		return nil
 	}
 
+	p.warmStart()
+
 	p.running = true
 	p.wg.Add(1)
 	go p.run()
@@ -89,6 +157,32 @@ func (p *Pipeline) IsRunning() bool {
 	return p.running
 }
 
+// RecentDecisions returns up to limit of the latest decisions, newest first
+func (p *Pipeline) RecentDecisions(limit int) []*models.ScalingDecision {
+	p.decisionsMu.RLock()
+	defer p.decisionsMu.RUnlock()
+
+	if limit <= 0 || limit > len(p.decisions) {
+		limit = len(p.decisions)
+	}
+
+	recent := make([]*models.ScalingDecision, limit)
+	for i := 0; i < limit; i++ {
+		recent[i] = p.decisions[len(p.decisions)-1-i]
+	}
+	return recent
+}
+
+func (p *Pipeline) recordDecision(scalingDecision *models.ScalingDecision) {
+	p.decisionsMu.Lock()
+	defer p.decisionsMu.Unlock()
+
+	p.decisions = append(p.decisions, scalingDecision)
+	if len(p.decisions) > maxRecentDecisions {
+		p.decisions = p.decisions[len(p.decisions)-maxRecentDecisions:]
+	}
+}
+
 func (p *Pipeline) run() {
 	defer p.wg.Done()
 
@@ -126,31 +220,117 @@ func (p *Pipeline) runCycle() {
 	}
 	p.metrics.IncCollections(clusterID)
 
-	// Step 2: Analyze metrics
-	analyzed := p.analyze(metricsData)
-	p.metrics.SetCPU(clusterID, analyzed.AvgCPU)
-	p.metrics.SetMemory(clusterID, analyzed.AvgMemory)
+	// Held from here so the cluster state stays current through the decision
+	p.scaleMu.Lock()
+	defer p.scaleMu.Unlock()
 
-	// Step 3: Get current cluster state
-	state, err := p.config.Scaler.GetClusterState(ctx, clusterID)
-	if err != nil {
-		logger.WithCluster(clusterID).Errorf("Failed to get cluster state: %v", err)
-		p.config.EventPublisher.Error(clusterID, "Failed to get cluster state", err)
+	p.expireRequest(ctx)
+
+	// Step 2: Get current cluster state. Metrics are still analyzed when it can't be
+	// loaded, only the server count check and the decision are skipped.
+	state, stateErr := p.config.Scaler.GetClusterState(ctx, clusterID)
+
+	// Step 3: Validate and analyze metrics. Metrics that failed their checks are
+	// analyzed without joining the history, sustained tracking, forecasts or the
+	// stored metrics.
+	var analyzed *models.AnalyzedMetrics
+	var prediction *models.Prediction
+	quality := p.checkQuality(metricsData, state)
+	switch quality {
+	case models.DataQualitySkip:
+		return
+	case models.DataQualityHoldLast:
+		analyzed = p.lastGood
+		prediction = p.predict(ctx, analyzed)
+	case models.DataQualityBlockScaleDown:
+		analyzed = p.evaluate(metricsData)
+	default:
+		p.config.EventPublisher.MetricCollected(clusterID, metricsData)
+		analyzed = p.analyze(metricsData)
+		p.metrics.SetCPU(clusterID, analyzed.AvgCPU)
+		p.metrics.SetMemory(clusterID, analyzed.AvgMemory)
+		p.analyzeServers(metricsData)
+		p.lastGood = analyzed
+		prediction = p.predict(ctx, analyzed)
+	}
+
+	if stateErr != nil {
+		logger.WithCluster(clusterID).Errorf("Failed to get cluster state: %v", stateErr)
+		p.config.EventPublisher.Error(clusterID, "Failed to get cluster state", stateErr)
 		return
 	}
 	p.metrics.SetServerCount(clusterID, state.ActiveServers)
 
-	// Step 4: Make scaling decision
+	// Step 4: Make scaling decision, unless an operator pinned the cluster size
 	decisionStart := time.Now()
-	scalingDecision := p.decide(analyzed, state)
+	var scalingDecision *models.ScalingDecision
+	if pin := p.activePin(); pin != nil {
+		scalingDecision = newManualDecision(clusterID, state, pin.DesiredServers, "pinned", &pin.PinnedBy)
+	} else {
+		scalingDecision = p.config.DecisionEngine.Decide(analyzed, prediction, state)
+	}
 	p.metrics.SetDecisionLatency(clusterID, time.Since(decisionStart))
 	p.metrics.IncDecision(clusterID, string(scalingDecision.Action))
 
+	// Freezes and approvals are checked before publishing so the decision carries them
+	observe := p.observeOnly.Load()
+	blocked := quality == models.DataQualityBlockScaleDown && scalingDecision.ShouldExecute() && p.blockScaleDown(scalingDecision)
+	frozen := scalingDecision.ShouldExecute() && !blocked && p.frozen(ctx, scalingDecision)
+	var held bool
+	var holdErr error
+	if scalingDecision.ShouldExecute() && !blocked && !frozen && !observe {
+		held, holdErr = p.holdForApproval(ctx, scalingDecision)
+	}
+	p.publishDecision(scalingDecision)
+
 	// Step 5: Execute scaling if needed
-	if scalingDecision.ShouldExecute() {
-		p.execute(ctx, scalingDecision)
+	outcome := models.OutcomeMaintained
+	var execErr error
+	switch {
+	case blocked:
+		outcome = models.OutcomeDataQualityBlocked
+	case frozen:
+		outcome = models.OutcomeFrozen
+	case holdErr != nil:
+		outcome, execErr = models.OutcomeFailed, holdErr
+		logger.WithCluster(clusterID).Errorf("Scale-up held without an approvable request: %v", holdErr)
+	case held:
+		outcome = models.OutcomePendingApproval
+	case scalingDecision.ShouldExecute() && observe:
+		outcome = p.observe(scalingDecision)
+	case scalingDecision.ShouldExecute():
+		outcome, execErr = p.execute(ctx, scalingDecision)
 		p.metrics.IncScalingEvent(clusterID, string(scalingDecision.Action))
+	case scalingDecision.CooldownActive:
+		outcome = models.OutcomeSuppressed
 	}
+
+	p.recordOutcome(ctx, scalingDecision, outcome, execErr)
+
+	p.checkDamping()
+}
+
+// checkDamping alerts when flap damping switches on or relaxes
+func (p *Pipeline) checkDamping() {
+	clusterID := p.config.ClusterID
+	damping := p.config.DecisionEngine.Damping(clusterID)
+	if damping.Active == p.damped {
+		return
+	}
+	p.damped = damping.Active
+
+	if damping.Active {
+		logger.WithCluster(clusterID).Warnf(
+			"Scaling oscillation detected (%d reversals), damping scale-down", damping.Reversals,
+		)
+		p.config.EventPublisher.Alert(clusterID, models.SeverityWarning,
+			"Scaling oscillation detected, damping scale-down", damping)
+		return
+	}
+
+	logger.WithCluster(clusterID).Info("Cluster stabilised, scale-down damping relaxed")
+	p.config.EventPublisher.Alert(clusterID, models.SeverityInfo,
+		"Cluster stabilised, scale-down damping relaxed", damping)
 }
 
 func (p *Pipeline) collect(ctx context.Context) (*models.ClusterMetrics, error) {
@@ -158,14 +338,22 @@ func (p *Pipeline) collect(ctx context.Context) (*models.ClusterMetrics, error)
 	if err != nil {
 		return nil, err
 	}
-
-	p.config.EventPublisher.MetricCollected(p.config.ClusterID, metricsData)
 	return metricsData, nil
 }
 
 func (p *Pipeline) analyze(metricsData *models.ClusterMetrics) *models.AnalyzedMetrics {
 	analyzed := p.config.Analyzer.Analyze(metricsData)
 	p.config.SustainedTracker.Update(p.config.ClusterID, analyzed, p.config.AnalyzerConfig)
+	return p.publishAnalyzed(analyzed)
+}
+
+// evaluate analyzes metrics that failed their data-quality checks, leaving the
+// analyzer history and sustained start times as they were
+func (p *Pipeline) evaluate(metricsData *models.ClusterMetrics) *models.AnalyzedMetrics {
+	return p.publishAnalyzed(p.config.Analyzer.Evaluate(metricsData))
+}
+
+func (p *Pipeline) publishAnalyzed(analyzed *models.AnalyzedMetrics) *models.AnalyzedMetrics {
 	p.config.EventPublisher.MetricAnalyzed(p.config.ClusterID, analyzed)
 
 	if analyzed.IsCritical() {
@@ -180,13 +368,104 @@ func (p *Pipeline) analyze(metricsData *models.ClusterMetrics) *models.AnalyzedM
 	return analyzed
 }
 
-func (p *Pipeline) decide(analyzed *models.AnalyzedMetrics, state *models.ClusterState) *models.ScalingDecision {
-	scalingDecision := p.config.DecisionEngine.Decide(analyzed, nil, state)
+func (p *Pipeline) predict(ctx context.Context, analyzed *models.AnalyzedMetrics) *models.Prediction {
+	if p.config.Predictor == nil {
+		return nil
+	}
+
+	prediction, err := p.config.Predictor.Predict(ctx, predictor.Input{
+		ClusterID: p.config.ClusterID,
+		Timestamp: time.Now(),
+		Current:   analyzed,
+		History:   p.config.Analyzer.GetHistory(p.config.ClusterID),
+	})
+	if err != nil {
+		if !errors.Is(err, predictor.ErrInsufficientData) {
+			logger.WithCluster(p.config.ClusterID).Warnf("Prediction failed: %v", err)
+		}
+		return nil
+	}
+
+	logger.WithCluster(p.config.ClusterID).Debugf(
+		"Predicted cpu=%.1f%% at %s (confidence: %.2f, model: %s)",
+		prediction.PredictedCPU, prediction.ForecastTime.Format(time.RFC3339),
+		prediction.Confidence, prediction.ModelVersion,
+	)
+	p.config.EventPublisher.PredictionMade(p.config.ClusterID, prediction)
+
+	// Shadow forecasts are only recorded for accuracy tracking
+	if p.config.ShadowPredictions {
+		return nil
+	}
+
+	return prediction
+}
+
+func (p *Pipeline) publishDecision(scalingDecision *models.ScalingDecision) {
+	p.recordDecision(scalingDecision)
 	p.config.EventPublisher.DecisionMade(p.config.ClusterID, scalingDecision)
-	return scalingDecision
 }
 
-func (p *Pipeline) execute(ctx context.Context, scalingDecision *models.ScalingDecision) {
+// frozen reports whether an open freeze window blocks the decision, marking it
+// with the freeze that did. Freezes that can't be loaded don't block scaling.
+func (p *Pipeline) frozen(ctx context.Context, scalingDecision *models.ScalingDecision) bool {
+	if p.config.Freezes == nil {
+		return false
+	}
+
+	clusterID := p.config.ClusterID
+	freezes, err := p.config.Freezes.ActiveFreezes(ctx, clusterID, time.Now())
+	if err != nil {
+		logger.WithCluster(clusterID).Warnf("Failed to load freeze windows, scaling anyway: %v", err)
+		return false
+	}
+
+	for _, freeze := range freezes {
+		if !freeze.Blocks(scalingDecision.Action, scalingDecision.IsEmergency) {
+			continue
+		}
+		scalingDecision.Reason = "freeze_active"
+		scalingDecision.Freeze = freeze.Name
+		logger.WithCluster(clusterID).Infof(
+			"Freeze %q active until %s, suppressed %s %d -> %d servers",
+			freeze.Name, freeze.ActiveUntil.Format(time.RFC3339),
+			scalingDecision.Action, scalingDecision.CurrentServers, scalingDecision.TargetServers,
+		)
+		return true
+	}
+	return false
+}
+
+// observe records the scaling action the pipeline would have taken without calling the scaler.
+// Cooldowns still start so the recorded cadence matches what managed mode would do.
+func (p *Pipeline) observe(scalingDecision *models.ScalingDecision) models.DecisionOutcome {
+	clusterID := p.config.ClusterID
+	p.recordAction(scalingDecision)
+	p.config.EventPublisher.WouldScale(clusterID, scalingDecision)
+
+	logger.WithCluster(clusterID).Infof(
+		"Observe mode: would have scaled %s %d -> %d servers (reason: %s)",
+		scalingDecision.Action,
+		scalingDecision.CurrentServers,
+		scalingDecision.TargetServers,
+		scalingDecision.Reason,
+	)
+
+	return models.OutcomeObserved
+}
+
+// recordAction starts the cooldowns after a scaling action. Manual scales and pin
+// enforcement are the operator's call, so only automatic actions count towards
+// flap detection.
+func (p *Pipeline) recordAction(scalingDecision *models.ScalingDecision) {
+	if scalingDecision.InitiatedBy != nil {
+		p.config.DecisionEngine.RecordScaling(p.config.ClusterID)
+		return
+	}
+	p.config.DecisionEngine.RecordScalingAction(p.config.ClusterID, scalingDecision.Action)
+}
+
+func (p *Pipeline) execute(ctx context.Context, scalingDecision *models.ScalingDecision) (models.DecisionOutcome, error) {
 	clusterID := p.config.ClusterID
 	p.config.EventPublisher.ScalingStarted(clusterID, scalingDecision)
 
@@ -205,14 +484,14 @@ func (p *Pipeline) execute(ctx context.Context, scalingDecision *models.ScalingD
 
 	if err != nil {
 		p.config.EventPublisher.ScalingFailed(clusterID, scalingDecision.Reason, err)
-		return
+		return models.OutcomeFailed, err
 	}
 
-	p.config.DecisionEngine.RecordScaling(clusterID)
+	p.recordAction(scalingDecision)
 
-	status := models.ScalingEventSuccess
+	status, outcome := models.ScalingEventSuccess, models.OutcomeExecuted
 	if result.PartialSuccess {
-		status = models.ScalingEventPartial
+		status, outcome = models.ScalingEventPartial, models.OutcomePartial
 	}
 
 	scalingEvent := models.NewScalingEvent(*scalingDecision, status)
@@ -224,4 +503,6 @@ func (p *Pipeline) execute(ctx context.Context, scalingDecision *models.ScalingD
 		scalingDecision.CurrentServers,
 		scalingDecision.TargetServers,
 	)
-}
\ No newline at end of file
+
+	return outcome, nil
+}
@@ -0,0 +1,81 @@
+package orchestrator
+
+import (
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// checkQuality validates a cycle's metrics against the scaler's state and reports
+// any issues found. It returns the data-quality policy to apply, or an empty policy
+// when the metrics are fine. The server count check is skipped when state is nil.
+func (p *Pipeline) checkQuality(metricsData *models.ClusterMetrics, state *models.ClusterState) models.DataQualityPolicy {
+	if p.config.DataQuality == nil {
+		return ""
+	}
+
+	clusterID := p.config.ClusterID
+	now := time.Now()
+
+	if !p.config.DataQuality.ChecksServerCount() {
+		state = nil
+	}
+
+	issues := p.config.DataQuality.Check(metricsData, state, p.config.CollectInterval, now)
+	if len(issues) == 0 {
+		return ""
+	}
+
+	policy := p.config.DataQuality.Policy()
+	applied := policy
+	if policy == models.DataQualityHoldLast && !p.canHoldLast(now) {
+		applied = models.DataQualitySkip
+	}
+
+	report := &models.DataQualityReport{
+		ClusterID:        clusterID,
+		Timestamp:        now,
+		MetricsTimestamp: metricsData.Timestamp,
+		ServerCount:      len(metricsData.Servers),
+		Issues:           issues,
+		Policy:           policy,
+		Applied:          applied,
+	}
+	if state != nil {
+		expected := state.ActiveServers
+		report.ExpectedServers = &expected
+	}
+
+	logger.WithCluster(clusterID).Warnf(
+		"Collected metrics failed %d data-quality check(s) (first: %s), applying %s",
+		len(issues), issues[0].Message, applied,
+	)
+	p.config.EventPublisher.DataQuality(report)
+
+	return applied
+}
+
+// canHoldLast reports whether the last good analysis is recent enough to stand in
+// for metrics that failed their checks. It goes stale on the same schedule as metrics.
+func (p *Pipeline) canHoldLast(now time.Time) bool {
+	if p.lastGood == nil {
+		return false
+	}
+	return now.Sub(p.lastGood.Timestamp) <= p.config.DataQuality.MaxAge(p.config.CollectInterval)
+}
+
+// blockScaleDown suppresses a scale-down decided on metrics that failed their checks.
+// Pinned sizes don't come from metrics and aren't blocked.
+func (p *Pipeline) blockScaleDown(scalingDecision *models.ScalingDecision) bool {
+	if scalingDecision.Action != models.ActionScaleDown || scalingDecision.InitiatedBy != nil {
+		return false
+	}
+
+	scalingDecision.Reason = "data_quality_blocked"
+	logger.WithCluster(p.config.ClusterID).Infof(
+		"Suppressed scale-down %d -> %d servers on metrics that failed data-quality checks",
+		scalingDecision.CurrentServers, scalingDecision.TargetServers,
+	)
+	return true
+}
@@ -0,0 +1,55 @@
+package orchestrator
+
+import (
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// Servers returns the latest per-server report, nil before the first analysis
+func (p *Pipeline) Servers() *models.ServerReport {
+	p.serversMu.RLock()
+	defer p.serversMu.RUnlock()
+	return p.servers
+}
+
+// analyzeServers runs the per-server analysis and alerts when a server turns hot
+// or stale, or the cluster's load becomes imbalanced. Conditions that carry over
+// from the previous cycle aren't alerted again.
+func (p *Pipeline) analyzeServers(metricsData *models.ClusterMetrics) {
+	if p.config.ServerAnalyzer == nil {
+		return
+	}
+
+	clusterID := p.config.ClusterID
+	report := p.config.ServerAnalyzer.Analyze(metricsData)
+
+	p.serversMu.Lock()
+	previous := p.servers
+	p.servers = report
+	p.serversMu.Unlock()
+
+	wasStatus := func(serverID string, status models.ServerStatus) bool {
+		if previous == nil {
+			return false
+		}
+		server, ok := previous.Server(serverID)
+		return ok && server.Status == status
+	}
+
+	for _, server := range report.ServersWith(models.ServerStatusHot) {
+		if !wasStatus(server.ServerID, models.ServerStatusHot) {
+			logger.WithCluster(clusterID).Warnf("Server %s is hot (cpu=%.1f%%)", server.ServerID, server.CPUUsage)
+			p.config.EventPublisher.ServerHot(clusterID, server)
+		}
+	}
+	for _, server := range report.ServersWith(models.ServerStatusStale) {
+		if !wasStatus(server.ServerID, models.ServerStatusStale) {
+			logger.WithCluster(clusterID).Warnf("Server %s reports stale metrics", server.ServerID)
+			p.config.EventPublisher.ServerStale(clusterID, server)
+		}
+	}
+	if report.Imbalanced && (previous == nil || !previous.Imbalanced) {
+		logger.WithCluster(clusterID).Warnf("Load imbalance across servers (variation=%.2f)", report.CPUVariation)
+		p.config.EventPublisher.LoadImbalance(report)
+	}
+}
@@ -0,0 +1,52 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// DecisionStore persists the record of every decision a pipeline makes
+type DecisionStore interface {
+	Insert(ctx context.Context, record *models.DecisionRecord) error
+}
+
+// RequestStore persists scaling requests as they open and resolve
+type RequestStore interface {
+	Save(ctx context.Context, request *models.ScalingRequest) error
+}
+
+// Records and requests are written by the pipeline itself rather than through the event bus,
+// which drops events when a subscriber falls behind. The write outlives the
+// caller's context so a record isn't lost to a cycle or request running late.
+const storeTimeout = 5 * time.Second
+
+// recordOutcome stores what became of a decision and publishes the record
+func (p *Pipeline) recordOutcome(ctx context.Context, scalingDecision *models.ScalingDecision, outcome models.DecisionOutcome, err error) *models.DecisionRecord {
+	clusterID := p.config.ClusterID
+	record := models.NewDecisionRecord(*scalingDecision, outcome, err)
+
+	if p.config.Decisions != nil {
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+		defer cancel()
+		if err := p.config.Decisions.Insert(storeCtx, record); err != nil {
+			logger.WithCluster(clusterID).Errorf("Failed to persist scaling decision: %v", err)
+		}
+	}
+
+	p.config.EventPublisher.DecisionRecorded(clusterID, record)
+	return record
+}
+
+// saveRequest stores the request as it stands; without a store it does nothing
+func (p *Pipeline) saveRequest(ctx context.Context, request *models.ScalingRequest) error {
+	if p.config.Requests == nil {
+		return nil
+	}
+
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+	defer cancel()
+	return p.config.Requests.Save(storeCtx, request)
+}
@@ -0,0 +1,93 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/analyzer"
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// HistorySource loads a cluster's stored metrics, newest bucket first
+type HistorySource interface {
+	GetAggregated(ctx context.Context, clusterID string, from, to time.Time, bucketMinutes int) ([]queries.AggregatedMetricPoint, error)
+}
+
+const (
+	// Stored metrics are replayed as one snapshot per minute
+	warmStartBucketMinutes = 1
+
+	// Buckets further apart than this, or a latest bucket older than this,
+	// mean the cluster wasn't watched continuously
+	warmStartMaxGap = 2 * time.Minute
+)
+
+// warmStart rebuilds the analyzer history and sustained start times from stored
+// metrics, so trends and sustained durations carry over a restart instead of
+// starting from zero
+func (p *Pipeline) warmStart() {
+	if p.config.History == nil {
+		return
+	}
+
+	clusterID := p.config.ClusterID
+	historyLen := p.config.AnalyzerConfig.MaxHistoryLength
+	if historyLen == 0 {
+		historyLen = 30
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	from := now.Add(-time.Duration(historyLen*warmStartBucketMinutes) * time.Minute)
+	points, err := p.config.History.GetAggregated(ctx, clusterID, from, now, warmStartBucketMinutes)
+	if err != nil {
+		logger.WithCluster(clusterID).Warnf("Warm start skipped, failed to load stored metrics: %v", err)
+		return
+	}
+	if len(points) == 0 {
+		return
+	}
+
+	statistic := p.config.AnalyzerConfig.CPUStatistic
+	snapshots := make([]analyzer.Snapshot, len(points))
+	for i, point := range points {
+		snapshots[len(points)-1-i] = analyzer.Snapshot{
+			Timestamp:  point.Time,
+			AvgCPU:     point.AvgCPU,
+			AvgMemory:  point.AvgMemory,
+			ScalingCPU: statistic.Of(point.AvgCPU, point.MaxCPU, bucketPercentiles(point)),
+		}
+	}
+
+	p.config.Analyzer.Restore(clusterID, snapshots)
+
+	latest := snapshots[len(snapshots)-1]
+	if now.Sub(latest.Timestamp) <= warmStartMaxGap {
+		p.config.SustainedTracker.Restore(clusterID, snapshots, p.config.AnalyzerConfig, warmStartMaxGap)
+	}
+
+	logger.WithCluster(clusterID).Infof(
+		"Warm started from %d stored snapshots (latest %s)", len(snapshots), latest.Timestamp.Format(time.RFC3339),
+	)
+}
+
+// bucketPercentiles reads a bucket's CPU percentiles, falling back to its average
+// for any that weren't computed
+func bucketPercentiles(point queries.AggregatedMetricPoint) models.CPUPercentiles {
+	orAvg := func(v *float64) float64 {
+		if v == nil {
+			return point.AvgCPU
+		}
+		return *v
+	}
+	return models.CPUPercentiles{
+		P50: orAvg(point.P50CPU),
+		P90: orAvg(point.P90CPU),
+		P95: orAvg(point.P95CPU),
+		P99: orAvg(point.P99CPU),
+	}
+}
@@ -0,0 +1,89 @@
+package predictor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+)
+
+// PatternRefresher rebuilds weekly patterns from raw metrics history
+type PatternRefresher interface {
+	Refresh(ctx context.Context, from, to time.Time) (int64, error)
+}
+
+type AggregatorConfig struct {
+	Patterns PatternRefresher
+	Interval time.Duration
+	Lookback time.Duration
+}
+
+// PatternAggregator periodically rolls metrics_history into pattern_history
+type PatternAggregator struct {
+	config AggregatorConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPatternAggregator(cfg AggregatorConfig) *PatternAggregator {
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.Lookback == 0 {
+		cfg.Lookback = 28 * 24 * time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &PatternAggregator{
+		config: cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (a *PatternAggregator) Start() {
+	a.wg.Add(1)
+	go a.run()
+}
+
+func (a *PatternAggregator) Stop() {
+	a.cancel()
+	a.wg.Wait()
+}
+
+func (a *PatternAggregator) run() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	a.aggregate()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.aggregate()
+		}
+	}
+}
+
+func (a *PatternAggregator) aggregate() {
+	ctx, cancel := context.WithTimeout(a.ctx, time.Minute)
+	defer cancel()
+
+	// Only complete hours are aggregated so partial buckets don't skew the averages
+	to := time.Now().Truncate(time.Hour)
+	from := to.Add(-a.config.Lookback)
+
+	updated, err := a.config.Patterns.Refresh(ctx, from, to)
+	if err != nil {
+		logger.Errorf("Failed to aggregate usage patterns: %v", err)
+		return
+	}
+
+	logger.Debugf("Aggregated usage patterns: %d buckets updated", updated)
+}
@@ -0,0 +1,83 @@
+package predictor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+)
+
+// ActualsBackfiller records observed CPU against predictions whose forecast time has passed
+type ActualsBackfiller interface {
+	BackfillActuals(ctx context.Context, window time.Duration, before time.Time) (int64, error)
+}
+
+type BackfillConfig struct {
+	Predictions ActualsBackfiller
+	Interval    time.Duration
+	Window      time.Duration
+}
+
+// AccuracyTracker periodically backfills actual_cpu so forecasts can be scored
+type AccuracyTracker struct {
+	config BackfillConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewAccuracyTracker(cfg BackfillConfig) *AccuracyTracker {
+	if cfg.Interval == 0 {
+		cfg.Interval = 5 * time.Minute
+	}
+	if cfg.Window == 0 {
+		cfg.Window = time.Minute
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AccuracyTracker{
+		config: cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (t *AccuracyTracker) Start() {
+	t.wg.Add(1)
+	go t.run()
+}
+
+func (t *AccuracyTracker) Stop() {
+	t.cancel()
+	t.wg.Wait()
+}
+
+func (t *AccuracyTracker) run() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(t.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			t.backfill()
+		}
+	}
+}
+
+func (t *AccuracyTracker) backfill() {
+	ctx, cancel := context.WithTimeout(t.ctx, time.Minute)
+	defer cancel()
+
+	updated, err := t.config.Predictions.BackfillActuals(ctx, t.config.Window, time.Now())
+	if err != nil {
+		logger.Errorf("Failed to backfill prediction actuals: %v", err)
+		return
+	}
+
+	logger.Debugf("Backfilled actual CPU for %d predictions", updated)
+}
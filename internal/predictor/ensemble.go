@@ -0,0 +1,148 @@
+package predictor
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+const (
+	// Assumed error for a model whose forecasts haven't been scored yet
+	ensemblePriorError = 10.0
+
+	// Smoothing factor for each model's running absolute error
+	ensembleErrorAlpha = 0.3
+
+	// Spread between model forecasts, in CPU points, at which agreement drops to zero
+	ensembleAgreementScale = 20.0
+)
+
+type pendingForecast struct {
+	forecastTime time.Time
+	predictedCPU float64
+}
+
+// Ensemble runs several predictors side by side and blends their forecasts,
+// weighting each by its recent absolute error against observed CPU.
+// Errors are tracked in memory from the analyzed metrics each cycle provides.
+type Ensemble struct {
+	members []Predictor
+
+	mu      sync.Mutex
+	pending map[string][]pendingForecast
+	errors  map[string]float64
+}
+
+func NewEnsemble(members []Predictor) *Ensemble {
+	return &Ensemble{
+		members: members,
+		pending: make(map[string][]pendingForecast),
+		errors:  make(map[string]float64),
+	}
+}
+
+func (e *Ensemble) Name() string {
+	return TypeEnsemble
+}
+
+func (e *Ensemble) Predict(ctx context.Context, in Input) (*models.Prediction, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if in.Current != nil {
+		e.score(in.Timestamp, in.Current.AvgCPU)
+	}
+
+	var forecasts []*models.Prediction
+	for _, member := range e.members {
+		forecast, err := member.Predict(ctx, in)
+		if err != nil {
+			if !errors.Is(err, ErrInsufficientData) {
+				logger.WithCluster(in.ClusterID).Warnf("Ensemble member %s failed: %v", member.Name(), err)
+			}
+			continue
+		}
+
+		e.pending[member.Name()] = append(e.pending[member.Name()], pendingForecast{
+			forecastTime: forecast.ForecastTime,
+			predictedCPU: forecast.PredictedCPU,
+		})
+		forecasts = append(forecasts, forecast)
+	}
+
+	if len(forecasts) == 0 {
+		return nil, ErrInsufficientData
+	}
+
+	components := make([]models.PredictionComponent, len(forecasts))
+	var totalWeight float64
+	for i, forecast := range forecasts {
+		recentError, ok := e.errors[forecast.ModelVersion]
+		if !ok {
+			recentError = ensemblePriorError
+		}
+		components[i] = models.PredictionComponent{
+			ModelVersion: forecast.ModelVersion,
+			PredictedCPU: forecast.PredictedCPU,
+			Confidence:   forecast.Confidence,
+			Weight:       1 / (1 + recentError),
+			RecentError:  recentError,
+		}
+		totalWeight += components[i].Weight
+	}
+
+	var blended, memberConfidence float64
+	lowerBound, upperBound := math.Inf(1), math.Inf(-1)
+	for i := range components {
+		components[i].Weight /= totalWeight
+		blended += components[i].Weight * components[i].PredictedCPU
+		memberConfidence += components[i].Weight * components[i].Confidence
+
+		lower, upper := forecasts[i].PredictedCPU, forecasts[i].PredictedCPU
+		if forecasts[i].UpperBound > 0 {
+			lower, upper = forecasts[i].LowerBound, forecasts[i].UpperBound
+		}
+		lowerBound = math.Min(lowerBound, lower)
+		upperBound = math.Max(upperBound, upper)
+	}
+
+	// Agreement falls as the weighted spread between the models grows
+	var variance float64
+	for _, c := range components {
+		variance += c.Weight * (c.PredictedCPU - blended) * (c.PredictedCPU - blended)
+	}
+	agreement := math.Max(0, 1-math.Sqrt(variance)/ensembleAgreementScale)
+
+	prediction := models.NewPrediction(in.ClusterID, forecasts[0].ForecastTime, clampCPU(blended), agreement*memberConfidence)
+	prediction.LowerBound = clampCPU(lowerBound)
+	prediction.UpperBound = clampCPU(upperBound)
+	prediction.ModelVersion = e.Name()
+	prediction.Components = components
+	return prediction, nil
+}
+
+// score resolves forecasts that have come due against the observed CPU
+func (e *Ensemble) score(now time.Time, actualCPU float64) {
+	for name, forecasts := range e.pending {
+		remaining := forecasts[:0]
+		for _, f := range forecasts {
+			if f.forecastTime.After(now) {
+				remaining = append(remaining, f)
+				continue
+			}
+
+			absError := math.Abs(f.predictedCPU - actualCPU)
+			if previous, ok := e.errors[name]; ok {
+				e.errors[name] = ensembleErrorAlpha*absError + (1-ensembleErrorAlpha)*previous
+			} else {
+				e.errors[name] = absError
+			}
+		}
+		e.pending[name] = remaining
+	}
+}
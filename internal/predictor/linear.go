@@ -0,0 +1,78 @@
+package predictor
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+const linearMinSamples = 5
+
+// LinearTrend fits a least-squares line to recent CPU history and extrapolates it
+type LinearTrend struct {
+	forecastWindow time.Duration
+}
+
+func NewLinearTrend(forecastWindow time.Duration) *LinearTrend {
+	return &LinearTrend{forecastWindow: forecastWindow}
+}
+
+func (p *LinearTrend) Name() string {
+	return TypeLinearTrend
+}
+
+func (p *LinearTrend) Predict(ctx context.Context, in Input) (*models.Prediction, error) {
+	history := in.History
+	if len(history) < linearMinSamples {
+		return nil, ErrInsufficientData
+	}
+
+	origin := history[0].Timestamp
+	n := float64(len(history))
+
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range history {
+		x := s.Timestamp.Sub(origin).Seconds()
+		sumX += x
+		sumY += s.AvgCPU
+		sumXY += x * s.AvgCPU
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return nil, ErrInsufficientData
+	}
+
+	slope := (n*sumXY - sumX*sumY) / denominator
+	intercept := (sumY - slope*sumX) / n
+
+	// Goodness of fit: a flat series is perfectly explained by its mean
+	meanY := sumY / n
+	var ssTot, ssRes float64
+	for _, s := range history {
+		x := s.Timestamp.Sub(origin).Seconds()
+		fitted := intercept + slope*x
+		ssTot += (s.AvgCPU - meanY) * (s.AvgCPU - meanY)
+		ssRes += (s.AvgCPU - fitted) * (s.AvgCPU - fitted)
+	}
+	rSquared := 1.0
+	if ssTot > 0 {
+		rSquared = math.Max(0, 1-ssRes/ssTot)
+	}
+
+	forecastTime := in.Timestamp.Add(p.forecastWindow)
+	predicted := clampCPU(intercept + slope*forecastTime.Sub(origin).Seconds())
+
+	// Penalise short histories and long extrapolations relative to the observed span
+	span := history[len(history)-1].Timestamp.Sub(origin)
+	sampleFactor := math.Min(1, n/20)
+	extrapolationFactor := span.Seconds() / (span.Seconds() + p.forecastWindow.Seconds())
+	confidence := rSquared * sampleFactor * extrapolationFactor
+
+	prediction := models.NewPrediction(in.ClusterID, forecastTime, predicted, confidence)
+	prediction.ModelVersion = p.Name()
+	return prediction, nil
+}
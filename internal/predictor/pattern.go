@@ -0,0 +1,59 @@
+package predictor
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// Number of weeks of history after which a bucket is fully trusted
+const patternFullConfidenceSamples = 4
+
+// PatternSource looks up the weekly usage pattern for a cluster
+type PatternSource interface {
+	GetBucket(ctx context.Context, clusterID string, dayOfWeek, hourOfDay int) (*models.PatternRecord, error)
+}
+
+// PatternMatching forecasts CPU from the historical average of the matching weekday/hour bucket
+type PatternMatching struct {
+	patterns       PatternSource
+	forecastWindow time.Duration
+}
+
+func NewPatternMatching(patterns PatternSource, forecastWindow time.Duration) *PatternMatching {
+	return &PatternMatching{
+		patterns:       patterns,
+		forecastWindow: forecastWindow,
+	}
+}
+
+func (p *PatternMatching) Name() string {
+	return TypePatternMatching
+}
+
+func (p *PatternMatching) Predict(ctx context.Context, in Input) (*models.Prediction, error) {
+	forecastTime := in.Timestamp.Add(p.forecastWindow)
+	bucket := forecastTime.UTC()
+
+	record, err := p.patterns.GetBucket(ctx, in.ClusterID, int(bucket.Weekday()), bucket.Hour())
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.SampleCount == 0 {
+		return nil, ErrInsufficientData
+	}
+
+	prediction := models.NewPrediction(in.ClusterID, forecastTime, clampCPU(record.AvgCPU), patternConfidence(record))
+	prediction.ModelVersion = p.Name()
+	return prediction, nil
+}
+
+// patternConfidence grows with the number of weeks observed and shrinks as the
+// bucket's CPU varies more from week to week
+func patternConfidence(record *models.PatternRecord) float64 {
+	sampleFactor := math.Min(1, float64(record.SampleCount)/patternFullConfidenceSamples)
+	stability := 1 - math.Min(1, record.CPUStdDev/math.Max(record.AvgCPU, 1))
+	return sampleFactor * stability
+}
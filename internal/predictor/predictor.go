@@ -0,0 +1,97 @@
+package predictor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/analyzer"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+const (
+	TypeLinearTrend     = "linear_trend"
+	TypePatternMatching = "pattern_matching"
+	TypeHoltWinters     = "holt_winters"
+	TypeEnsemble        = "ensemble"
+)
+
+var (
+	ErrInsufficientData = errors.New("insufficient data for prediction")
+	ErrUnknownType      = errors.New("unknown predictor type")
+)
+
+// Input carries everything a predictor may use to build a forecast
+type Input struct {
+	ClusterID string
+	Timestamp time.Time
+	Current   *models.AnalyzedMetrics
+	History   []analyzer.Snapshot
+}
+
+// Predictor forecasts cluster CPU usage for a future point in time
+type Predictor interface {
+	// Name returns the model identifier stored as the prediction's model version
+	Name() string
+
+	// Predict returns a forecast for Input.Timestamp plus the forecast window
+	Predict(ctx context.Context, in Input) (*models.Prediction, error)
+}
+
+type Config struct {
+	Types          []string
+	ForecastWindow time.Duration
+	Patterns       PatternSource
+	Smoothing      SmoothingConfig
+}
+
+func New(cfg Config) (Predictor, error) {
+	if cfg.ForecastWindow == 0 {
+		cfg.ForecastWindow = 15 * time.Minute
+	}
+
+	if len(cfg.Types) == 0 {
+		return nil, fmt.Errorf("%w: no predictor type configured", ErrUnknownType)
+	}
+	if len(cfg.Types) == 1 {
+		return newModel(cfg.Types[0], cfg)
+	}
+
+	// Several models run side by side and are blended into one forecast
+	members := make([]Predictor, 0, len(cfg.Types))
+	for _, modelType := range cfg.Types {
+		member, err := newModel(modelType, cfg)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return NewEnsemble(members), nil
+}
+
+func newModel(modelType string, cfg Config) (Predictor, error) {
+	switch modelType {
+	case TypeLinearTrend:
+		return NewLinearTrend(cfg.ForecastWindow), nil
+	case TypePatternMatching:
+		if cfg.Patterns == nil {
+			return nil, fmt.Errorf("%s predictor requires a pattern source", TypePatternMatching)
+		}
+		return NewPatternMatching(cfg.Patterns, cfg.ForecastWindow), nil
+	case TypeHoltWinters:
+		return NewHoltWinters(cfg.Smoothing, cfg.ForecastWindow), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, modelType)
+	}
+}
+
+func clampCPU(value float64) float64 {
+	if value < 0 {
+		return 0
+	}
+	if value > 100 {
+		return 100
+	}
+	return value
+}
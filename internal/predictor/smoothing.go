@@ -0,0 +1,176 @@
+package predictor
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+const (
+	smoothingMinSamples = 5
+
+	// z-score for a 95% prediction interval
+	smoothingIntervalZ = 1.96
+)
+
+type SmoothingConfig struct {
+	Alpha        float64 // level smoothing
+	Beta         float64 // trend smoothing
+	Gamma        float64 // seasonal smoothing
+	Damping      float64 // trend damping, 1 disables damping
+	SeasonLength int     // samples per season, 0 disables the seasonal component
+}
+
+// HoltWinters forecasts CPU with double exponential smoothing, adding an
+// additive seasonal component once two full seasons of history are available.
+// It only uses the analyzer's in-memory history, so it keeps working without a database.
+type HoltWinters struct {
+	config         SmoothingConfig
+	forecastWindow time.Duration
+}
+
+func NewHoltWinters(cfg SmoothingConfig, forecastWindow time.Duration) *HoltWinters {
+	if cfg.Alpha == 0 {
+		cfg.Alpha = 0.5
+	}
+	if cfg.Beta == 0 {
+		cfg.Beta = 0.3
+	}
+	if cfg.Gamma == 0 {
+		cfg.Gamma = 0.1
+	}
+	if cfg.Damping == 0 {
+		cfg.Damping = 0.98
+	}
+
+	return &HoltWinters{
+		config:         cfg,
+		forecastWindow: forecastWindow,
+	}
+}
+
+func (p *HoltWinters) Name() string {
+	return TypeHoltWinters
+}
+
+func (p *HoltWinters) Predict(ctx context.Context, in Input) (*models.Prediction, error) {
+	history := in.History
+	if len(history) < smoothingMinSamples {
+		return nil, ErrInsufficientData
+	}
+
+	span := history[len(history)-1].Timestamp.Sub(history[0].Timestamp)
+	if span <= 0 {
+		return nil, ErrInsufficientData
+	}
+
+	// Convert the forecast window into a number of steps at the observed sample rate
+	step := span / time.Duration(len(history)-1)
+	forecastTime := in.Timestamp.Add(p.forecastWindow)
+	horizon := int(math.Ceil(float64(forecastTime.Sub(history[len(history)-1].Timestamp)) / float64(step)))
+	if horizon < 1 {
+		horizon = 1
+	}
+
+	values := make([]float64, len(history))
+	for i, s := range history {
+		values[i] = s.AvgCPU
+	}
+
+	var forecast, rmse float64
+	if m := p.config.SeasonLength; m > 1 && len(values) >= 2*m {
+		forecast, rmse = p.triple(values, horizon)
+	} else {
+		forecast, rmse = p.double(values, horizon)
+	}
+
+	// Error variance grows with the horizon as the level keeps absorbing new shocks
+	halfWidth := smoothingIntervalZ * rmse * math.Sqrt(1+float64(horizon-1)*p.config.Alpha*p.config.Alpha)
+	confidence := math.Max(0, 1-halfWidth/50)
+
+	predicted := clampCPU(forecast)
+	prediction := models.NewPrediction(in.ClusterID, forecastTime, predicted, confidence)
+	prediction.LowerBound = clampCPU(forecast - halfWidth)
+	prediction.UpperBound = clampCPU(forecast + halfWidth)
+	prediction.ModelVersion = p.Name()
+	return prediction, nil
+}
+
+// double runs Holt's linear method with a damped trend and returns the
+// h-step forecast along with the RMSE of its one-step-ahead errors
+func (p *HoltWinters) double(values []float64, horizon int) (float64, float64) {
+	alpha, beta, phi := p.config.Alpha, p.config.Beta, p.config.Damping
+
+	level := values[0]
+	trend := values[1] - values[0]
+
+	var sumSquares float64
+	for t := 1; t < len(values); t++ {
+		expected := level + phi*trend
+		err := values[t] - expected
+		sumSquares += err * err
+
+		prevLevel := level
+		level = alpha*values[t] + (1-alpha)*(level+phi*trend)
+		trend = beta*(level-prevLevel) + (1-beta)*phi*trend
+	}
+
+	rmse := math.Sqrt(sumSquares / float64(len(values)-1))
+	return level + dampedSum(phi, horizon)*trend, rmse
+}
+
+// triple adds an additive seasonal component on top of the damped trend
+func (p *HoltWinters) triple(values []float64, horizon int) (float64, float64) {
+	alpha, beta, gamma, phi := p.config.Alpha, p.config.Beta, p.config.Gamma, p.config.Damping
+	m := p.config.SeasonLength
+
+	firstMean := mean(values[:m])
+	secondMean := mean(values[m : 2*m])
+
+	level := firstMean
+	trend := (secondMean - firstMean) / float64(m)
+	seasonal := make([]float64, len(values)+horizon)
+	for i := 0; i < m; i++ {
+		seasonal[i] = values[i] - firstMean
+	}
+
+	var sumSquares float64
+	var count int
+	for t := m; t < len(values); t++ {
+		expected := level + phi*trend + seasonal[t-m]
+		err := values[t] - expected
+		sumSquares += err * err
+		count++
+
+		prevLevel := level
+		level = alpha*(values[t]-seasonal[t-m]) + (1-alpha)*(level+phi*trend)
+		trend = beta*(level-prevLevel) + (1-beta)*phi*trend
+		seasonal[t] = gamma*(values[t]-level) + (1-gamma)*seasonal[t-m]
+	}
+
+	n := len(values)
+	season := seasonal[n-m+(horizon-1)%m]
+	rmse := math.Sqrt(sumSquares / float64(count))
+	return level + dampedSum(phi, horizon)*trend + season, rmse
+}
+
+// dampedSum returns phi + phi^2 + ... + phi^h
+func dampedSum(phi float64, h int) float64 {
+	if phi == 1 {
+		return float64(h)
+	}
+	return phi * (1 - math.Pow(phi, float64(h))) / (1 - phi)
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
@@ -0,0 +1,67 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// FreezeSource lists the freeze windows that apply to a cluster
+type FreezeSource interface {
+	GetEnabledForCluster(ctx context.Context, clusterID string) ([]*models.FreezeWindow, error)
+}
+
+// FreezeChecker resolves which of a cluster's freeze windows are open
+type FreezeChecker struct {
+	source FreezeSource
+}
+
+func NewFreezeChecker(source FreezeSource) *FreezeChecker {
+	return &FreezeChecker{source: source}
+}
+
+// ActiveFreezes returns the cluster's freeze windows that are open at now
+func (c *FreezeChecker) ActiveFreezes(ctx context.Context, clusterID string, now time.Time) ([]models.ActiveFreeze, error) {
+	freezes, err := c.source.GetEnabledForCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := []models.ActiveFreeze{}
+	for _, freeze := range freezes {
+		if until, ok := freezeEnd(clusterID, freeze, now); ok {
+			active = append(active, models.ActiveFreeze{FreezeWindow: *freeze, ActiveUntil: until})
+		}
+	}
+	return active, nil
+}
+
+// freezeEnd returns when the freeze's current window closes, if one is open at now
+func freezeEnd(clusterID string, freeze *models.FreezeWindow, now time.Time) (time.Time, bool) {
+	if !freeze.IsRecurring() {
+		if freeze.StartsAt == nil || freeze.EndsAt == nil ||
+			now.Before(*freeze.StartsAt) || !now.Before(*freeze.EndsAt) {
+			return time.Time{}, false
+		}
+		return *freeze.EndsAt, true
+	}
+
+	cron, err := Parse(freeze.Cron)
+	if err != nil {
+		logger.WithCluster(clusterID).Warnf("Skipping freeze window %q: %v", freeze.Name, err)
+		return time.Time{}, false
+	}
+	loc, err := freeze.Location()
+	if err != nil {
+		logger.WithCluster(clusterID).Warnf("Skipping freeze window %q: %v", freeze.Name, err)
+		return time.Time{}, false
+	}
+
+	start, ok := cron.ActiveWindow(now.In(loc), freeze.Duration())
+	if !ok {
+		return time.Time{}, false
+	}
+	return start.Add(freeze.Duration()), true
+}
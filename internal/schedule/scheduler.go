@@ -0,0 +1,160 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/decision"
+	"github.com/OldStager01/cloud-autoscaler/internal/events"
+	"github.com/OldStager01/cloud-autoscaler/internal/logger"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+// ScheduleSource lists the schedules the scheduler should evaluate
+type ScheduleSource interface {
+	GetEnabled(ctx context.Context) ([]*models.ScalingSchedule, error)
+}
+
+// OverrideTarget applies schedule overrides to running cluster pipelines
+type OverrideTarget interface {
+	// ApplyOverride returns false when the cluster has no running pipeline
+	ApplyOverride(clusterID string, override decision.Override) bool
+	ClearOverride(clusterID, overrideID string)
+}
+
+type Config struct {
+	Schedules ScheduleSource
+	Target    OverrideTarget
+	Publisher *events.Publisher
+	Interval  time.Duration
+}
+
+type activeSchedule struct {
+	schedule  *models.ScalingSchedule
+	startedAt time.Time
+}
+
+// Scheduler periodically evaluates scaling schedules, applying an override to the
+// cluster's decision engine while a schedule's window is open and clearing it after
+type Scheduler struct {
+	config Config
+	active map[string]activeSchedule
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(cfg Config) *Scheduler {
+	if cfg.Interval == 0 {
+		cfg.Interval = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		config: cfg,
+		active: make(map[string]activeSchedule),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	s.Evaluate(time.Now())
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.Evaluate(time.Now())
+		}
+	}
+}
+
+// Evaluate opens and closes schedule windows as of now. Active overrides are
+// re-applied every time so a restarted pipeline picks them up again.
+func (s *Scheduler) Evaluate(now time.Time) {
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Second)
+	defer cancel()
+
+	schedules, err := s.config.Schedules.GetEnabled(ctx)
+	if err != nil {
+		logger.Errorf("Failed to load scaling schedules: %v", err)
+		return
+	}
+
+	seen := make(map[string]bool, len(schedules))
+	for _, schedule := range schedules {
+		start, ok := windowStart(schedule, now)
+		if !ok {
+			continue
+		}
+		seen[schedule.ID] = true
+
+		until := start.Add(schedule.Duration())
+		applied := s.config.Target.ApplyOverride(schedule.ClusterID, decision.Override{
+			ID:             schedule.ID,
+			Name:           schedule.Name,
+			MinServers:     schedule.MinServers,
+			MaxServers:     schedule.MaxServers,
+			DesiredServers: schedule.DesiredServers,
+			StartedAt:      start,
+			Until:          until,
+		})
+		if !applied {
+			// The cluster isn't running; the window opens once its pipeline starts
+			delete(seen, schedule.ID)
+			continue
+		}
+
+		if previous, exists := s.active[schedule.ID]; !exists || !previous.startedAt.Equal(start) {
+			logger.WithCluster(schedule.ClusterID).Infof("Scaling schedule %q started (until %s)", schedule.Name, until.Format(time.RFC3339))
+			s.config.Publisher.ScheduleStarted(schedule, until)
+		}
+		s.active[schedule.ID] = activeSchedule{schedule: schedule, startedAt: start}
+	}
+
+	// Windows that closed, or whose schedule was disabled or deleted
+	for id, active := range s.active {
+		if seen[id] {
+			continue
+		}
+		s.config.Target.ClearOverride(active.schedule.ClusterID, id)
+		delete(s.active, id)
+
+		logger.WithCluster(active.schedule.ClusterID).Infof("Scaling schedule %q ended", active.schedule.Name)
+		s.config.Publisher.ScheduleEnded(active.schedule)
+	}
+}
+
+// windowStart returns when the schedule's current window opened, if one is open at now
+func windowStart(schedule *models.ScalingSchedule, now time.Time) (time.Time, bool) {
+	cron, err := Parse(schedule.Cron)
+	if err != nil {
+		logger.WithCluster(schedule.ClusterID).Warnf("Skipping scaling schedule %q: %v", schedule.Name, err)
+		return time.Time{}, false
+	}
+	loc, err := schedule.Location()
+	if err != nil {
+		logger.WithCluster(schedule.ClusterID).Warnf("Skipping scaling schedule %q: %v", schedule.Name, err)
+		return time.Time{}, false
+	}
+
+	return cron.ActiveWindow(now.In(loc), schedule.Duration())
+}
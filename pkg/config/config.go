@@ -13,6 +13,7 @@ type Config struct {
 	Decision   DecisionConfig   `mapstructure:"decision"`
 	Predictor  PredictorConfig  `mapstructure:"predictor"`
 	Scaler     ScalerConfig     `mapstructure:"scaler"`
+	Scheduler  SchedulerConfig  `mapstructure:"scheduler"`
 	API        APIConfig        `mapstructure:"api"`
 	WebSocket  WebSocketConfig  `mapstructure:"websocket"`
 	Prometheus PrometheusConfig `mapstructure:"prometheus"`
@@ -58,6 +59,15 @@ type CollectorConfig struct {
 	Timeout        time.Duration        `mapstructure:"timeout"`
 	RetryAttempts  int                  `mapstructure:"retry_attempts"`
 	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	Quality        QualityConfig        `mapstructure:"quality"`
+}
+
+type QualityConfig struct {
+	Enabled              bool   `mapstructure:"enabled"`
+	Policy               string `mapstructure:"policy"`
+	StaleIntervals       int    `mapstructure:"stale_intervals"`
+	CheckServerCount     bool   `mapstructure:"check_server_count"`
+	ServerCountTolerance int    `mapstructure:"server_count_tolerance"`
 }
 
 type CircuitBreakerConfig struct {
@@ -71,12 +81,38 @@ type AnalyzerConfig struct {
 	SpikeThreshold    float64         `mapstructure:"spike_threshold"`
 	MaxHistoryLength  int             `mapstructure:"max_history_length"`
 	CriticalThreshold float64         `mapstructure:"critical_threshold"`
+	Anomaly           AnomalyConfig   `mapstructure:"anomaly"`
+	Servers           ServerConfig    `mapstructure:"servers"`
+	CPUStatistic      string          `mapstructure:"cpu_statistic"`
+	PercentileWindow  time.Duration   `mapstructure:"percentile_window"`
+}
+
+// AnomalyConfig selects the detector that scores CPU against each cluster's
+// rolling baseline. An empty detector disables anomaly detection.
+type AnomalyConfig struct {
+	Detector        string  `mapstructure:"detector"`
+	BaselineSamples int     `mapstructure:"baseline_samples"`
+	MinSamples      int     `mapstructure:"min_samples"`
+	Sensitivity     float64 `mapstructure:"sensitivity"`
+	MinDeviation    float64 `mapstructure:"min_deviation"`
+	EWMAAlpha       float64 `mapstructure:"ewma_alpha"`
+}
+
+// ServerConfig tunes the per-server analysis that finds hot servers, uneven load
+// and servers with stale metrics
+type ServerConfig struct {
+	HotCPUThreshold    float64       `mapstructure:"hot_cpu_threshold"`
+	HotCPUMargin       float64       `mapstructure:"hot_cpu_margin"`
+	ImbalanceThreshold float64       `mapstructure:"imbalance_threshold"`
+	ImbalanceMinCPU    float64       `mapstructure:"imbalance_min_cpu"`
+	StaleAfter         time.Duration `mapstructure:"stale_after"`
 }
 
 type ThresholdConfig struct {
 	CPUHigh    float64 `mapstructure:"cpu_high"`
 	CPULow     float64 `mapstructure:"cpu_low"`
 	MemoryHigh float64 `mapstructure:"memory_high"`
+	MemoryLow  float64 `mapstructure:"memory_low"`
 }
 
 type DecisionConfig struct {
@@ -88,13 +124,41 @@ type DecisionConfig struct {
 	MinServers              int           `mapstructure:"min_servers"`
 	MaxServers              int           `mapstructure:"max_servers"`
 	MaxScaleStep            int           `mapstructure:"max_scale_step"`
+	ScaleDownMode           string        `mapstructure:"scale_down_mode"`
+	MaxScaleDownStep        int           `mapstructure:"max_scale_down_step"`
+	MaxScaleDownPercent     float64       `mapstructure:"max_scale_down_percent"`
+	ScaleDownSafetyMargin   float64       `mapstructure:"scale_down_safety_margin"`
+	FlapDamping             FlapDampingConfig `mapstructure:"flap_damping"`
+}
+
+type FlapDampingConfig struct {
+	Enabled            bool          `mapstructure:"enabled"`
+	Window             time.Duration `mapstructure:"window"`
+	Reversals          int           `mapstructure:"reversals"`
+	CooldownMultiplier float64       `mapstructure:"cooldown_multiplier"`
+	HysteresisMargin   float64       `mapstructure:"hysteresis_margin"`
+	StableFor          time.Duration `mapstructure:"stable_for"`
 }
 
 type PredictorConfig struct {
-	Enabled        bool          `mapstructure:"enabled"`
-	Type           string        `mapstructure:"type"`
-	ForecastWindow time.Duration `mapstructure:"forecast_window"`
-	MinConfidence  float64       `mapstructure:"min_confidence"`
+	Enabled             bool          `mapstructure:"enabled"`
+	Type                []string      `mapstructure:"type"`
+	Shadow              bool          `mapstructure:"shadow"`
+	ForecastWindow      time.Duration `mapstructure:"forecast_window"`
+	MinConfidence       float64       `mapstructure:"min_confidence"`
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+	PatternLookback     time.Duration `mapstructure:"pattern_lookback"`
+	BackfillInterval    time.Duration `mapstructure:"backfill_interval"`
+	BackfillWindow      time.Duration `mapstructure:"backfill_window"`
+	Smoothing           SmoothingConfig `mapstructure:"smoothing"`
+}
+
+type SmoothingConfig struct {
+	Alpha        float64 `mapstructure:"alpha"`
+	Beta         float64 `mapstructure:"beta"`
+	Gamma        float64 `mapstructure:"gamma"`
+	Damping      float64 `mapstructure:"damping"`
+	SeasonLength int     `mapstructure:"season_length"`
 }
 
 type ScalerConfig struct {
@@ -103,6 +167,12 @@ type ScalerConfig struct {
 	DrainTimeout  time.Duration `mapstructure:"drain_timeout"`
 }
 
+// SchedulerConfig controls how often cron-based scaling schedules are evaluated
+type SchedulerConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
 type APIConfig struct {
 	Port            int           `mapstructure:"port"`
 	ReadTimeout     time.Duration `mapstructure:"read_timeout"`
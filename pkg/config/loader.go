@@ -68,13 +68,32 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("collector.retry_attempts", 3)
 	v.SetDefault("collector.circuit_breaker.max_failures", 5)
 	v.SetDefault("collector.circuit_breaker.timeout", "30s")
+	v.SetDefault("collector.quality.enabled", true)
+	v.SetDefault("collector.quality.policy", "block_scale_down")
+	v.SetDefault("collector.quality.stale_intervals", 3)
+	v.SetDefault("collector.quality.check_server_count", true)
+	v.SetDefault("collector.quality.server_count_tolerance", 0)
 
 	// Analyzer defaults
 	v.SetDefault("analyzer.thresholds.cpu_high", 80.0)
 	v.SetDefault("analyzer.thresholds.cpu_low", 30.0)
 	v.SetDefault("analyzer.thresholds.memory_high", 85.0)
+	v.SetDefault("analyzer.thresholds.memory_low", 40.0)
 	v.SetDefault("analyzer.trend_window", "5m")
 	v.SetDefault("analyzer.spike_threshold", 50.0)
+	v.SetDefault("analyzer.cpu_statistic", "avg")
+	v.SetDefault("analyzer.percentile_window", "0s")
+	v.SetDefault("analyzer.anomaly.detector", "")
+	v.SetDefault("analyzer.anomaly.baseline_samples", 20)
+	v.SetDefault("analyzer.anomaly.min_samples", 10)
+	v.SetDefault("analyzer.anomaly.sensitivity", 3.0)
+	v.SetDefault("analyzer.anomaly.min_deviation", 10.0)
+	v.SetDefault("analyzer.anomaly.ewma_alpha", 0.3)
+	v.SetDefault("analyzer.servers.hot_cpu_threshold", 90.0)
+	v.SetDefault("analyzer.servers.hot_cpu_margin", 25.0)
+	v.SetDefault("analyzer.servers.imbalance_threshold", 0.5)
+	v.SetDefault("analyzer.servers.imbalance_min_cpu", 50.0)
+	v.SetDefault("analyzer.servers.stale_after", "2m")
 
 	// Decision defaults
 	v.SetDefault("decision.cooldown_period", "5m")
@@ -82,18 +101,41 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("decision.min_servers", 2)
 	v.SetDefault("decision.max_servers", 50)
 	v.SetDefault("decision.max_scale_step", 3)
+	v.SetDefault("decision.scale_down_mode", "conservative")
+	v.SetDefault("decision.max_scale_down_percent", 25.0)
+	v.SetDefault("decision.scale_down_safety_margin", 10.0)
+	v.SetDefault("decision.flap_damping.enabled", true)
+	v.SetDefault("decision.flap_damping.window", "30m")
+	v.SetDefault("decision.flap_damping.reversals", 3)
+	v.SetDefault("decision.flap_damping.cooldown_multiplier", 3.0)
+	v.SetDefault("decision.flap_damping.hysteresis_margin", 10.0)
+	v.SetDefault("decision.flap_damping.stable_for", "30m")
 
 	// Predictor defaults
 	v.SetDefault("predictor.enabled", false)
-	v.SetDefault("predictor.type", "pattern_matching")
+	v.SetDefault("predictor.type", []string{"pattern_matching"})
 	v.SetDefault("predictor.forecast_window", "15m")
 	v.SetDefault("predictor.min_confidence", 0.7)
+	v.SetDefault("predictor.aggregation_interval", "1h")
+	v.SetDefault("predictor.pattern_lookback", "672h")
+	v.SetDefault("predictor.shadow", false)
+	v.SetDefault("predictor.backfill_interval", "5m")
+	v.SetDefault("predictor.backfill_window", "1m")
+	v.SetDefault("predictor.smoothing.alpha", 0.5)
+	v.SetDefault("predictor.smoothing.beta", 0.3)
+	v.SetDefault("predictor.smoothing.gamma", 0.1)
+	v.SetDefault("predictor.smoothing.damping", 0.98)
+	v.SetDefault("predictor.smoothing.season_length", 0)
 
 	// Scaler defaults
 	v.SetDefault("scaler.type", "simulator")
 	v.SetDefault("scaler.provision_time", "10s")
 	v.SetDefault("scaler.drain_timeout", "30s")
 
+	// Scheduler defaults
+	v.SetDefault("scheduler.enabled", true)
+	v.SetDefault("scheduler.interval", "30s")
+
 	// API defaults
 	v.SetDefault("api.port", 8080)
 	v.SetDefault("api.read_timeout", "15s")
@@ -3,6 +3,9 @@ package config
 import (
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 )
 
 func (c *Config) Validate() error {
@@ -47,6 +50,18 @@ func (c *Config) Validate() error {
 	if c.Collector.Timeout >= c.Collector.Interval {
 		errs = append(errs, errors.New("collector.timeout must be less than collector.interval"))
 	}
+	if quality := c.Collector.Quality; quality.Enabled {
+		validPolicies := map[string]bool{"skip": true, "hold_last": true, "block_scale_down": true}
+		if !validPolicies[quality.Policy] {
+			errs = append(errs, fmt.Errorf("collector.quality.policy must be one of: skip, hold_last, block_scale_down (got %q)", quality.Policy))
+		}
+		if quality.StaleIntervals < 1 {
+			errs = append(errs, errors.New("collector.quality.stale_intervals must be at least 1"))
+		}
+		if quality.ServerCountTolerance < 0 {
+			errs = append(errs, errors.New("collector.quality.server_count_tolerance must not be negative"))
+		}
+	}
 
 	// Analyzer validation
 	if c.Analyzer.Thresholds.CPUHigh <= c.Analyzer.Thresholds.CPULow {
@@ -59,6 +74,41 @@ func (c *Config) Validate() error {
 		errs = append(errs, errors.New("analyzer.thresholds.cpu_low must be between 0 and 100"))
 	}
 
+	if c.Analyzer.Thresholds.MemoryHigh <= c.Analyzer.Thresholds.MemoryLow {
+		errs = append(errs, errors.New("analyzer.thresholds.memory_high must be greater than memory_low"))
+	}
+	if c.Analyzer.Thresholds.MemoryHigh <= 0 || c.Analyzer.Thresholds.MemoryHigh > 100 {
+		errs = append(errs, errors.New("analyzer.thresholds.memory_high must be between 0 and 100"))
+	}
+	if c.Analyzer.CPUStatistic != "" && !models.CPUStatistic(c.Analyzer.CPUStatistic).Valid() {
+		errs = append(errs, fmt.Errorf("analyzer.cpu_statistic must be one of: avg, max, p50, p90, p95, p99 (got %q)", c.Analyzer.CPUStatistic))
+	}
+	if c.Analyzer.PercentileWindow < 0 {
+		errs = append(errs, errors.New("analyzer.percentile_window must not be negative"))
+	}
+	if servers := c.Analyzer.Servers; servers.HotCPUThreshold < 0 || servers.HotCPUThreshold > 100 ||
+		servers.HotCPUMargin < 0 || servers.ImbalanceThreshold < 0 || servers.StaleAfter < 0 {
+		errs = append(errs, errors.New("analyzer.servers: hot_cpu_threshold must be between 0 and 100, other settings must not be negative"))
+	}
+	if anomaly := c.Analyzer.Anomaly; anomaly.Detector != "" {
+		validDetectors := map[string]bool{"zscore": true, "ewma": true, "mad": true}
+		if !validDetectors[anomaly.Detector] {
+			errs = append(errs, fmt.Errorf("analyzer.anomaly.detector must be one of: zscore, ewma, mad (got %q)", anomaly.Detector))
+		}
+		if anomaly.MinSamples < 2 || anomaly.BaselineSamples < anomaly.MinSamples {
+			errs = append(errs, errors.New("analyzer.anomaly.min_samples must be at least 2 and no more than baseline_samples"))
+		}
+		if anomaly.BaselineSamples >= c.Analyzer.MaxHistoryLength && c.Analyzer.MaxHistoryLength > 0 {
+			errs = append(errs, errors.New("analyzer.anomaly.baseline_samples must be less than analyzer.max_history_length"))
+		}
+		if anomaly.Sensitivity <= 0 || anomaly.MinDeviation < 0 {
+			errs = append(errs, errors.New("analyzer.anomaly.sensitivity must be positive and min_deviation must not be negative"))
+		}
+		if anomaly.EWMAAlpha <= 0 || anomaly.EWMAAlpha > 1 {
+			errs = append(errs, errors.New("analyzer.anomaly.ewma_alpha must be between 0 and 1"))
+		}
+	}
+
 	// Decision validation
 	if c.Decision.MinServers <= 0 {
 		errs = append(errs, errors.New("decision.min_servers must be positive"))
@@ -72,6 +122,79 @@ func (c *Config) Validate() error {
 	if c.Decision.CooldownPeriod <= 0 {
 		errs = append(errs, errors.New("decision.cooldown_period must be positive"))
 	}
+	if c.Decision.ScaleDownMode != "" && c.Decision.ScaleDownMode != "conservative" && c.Decision.ScaleDownMode != "proportional" {
+		errs = append(errs, errors.New("decision.scale_down_mode must be one of: conservative, proportional"))
+	}
+	if c.Decision.MaxScaleDownStep < 0 {
+		errs = append(errs, errors.New("decision.max_scale_down_step must not be negative"))
+	}
+	if c.Decision.MaxScaleDownPercent < 0 || c.Decision.MaxScaleDownPercent > 100 {
+		errs = append(errs, errors.New("decision.max_scale_down_percent must be between 0 and 100"))
+	}
+	if c.Decision.ScaleDownSafetyMargin < 0 {
+		errs = append(errs, errors.New("decision.scale_down_safety_margin must not be negative"))
+	}
+	if flap := c.Decision.FlapDamping; flap.Enabled {
+		if flap.Window < 0 || flap.StableFor < 0 {
+			errs = append(errs, errors.New("decision.flap_damping window and stable_for must not be negative"))
+		}
+		if flap.Reversals < 0 {
+			errs = append(errs, errors.New("decision.flap_damping.reversals must not be negative"))
+		}
+		if flap.CooldownMultiplier != 0 && flap.CooldownMultiplier < 1 {
+			errs = append(errs, errors.New("decision.flap_damping.cooldown_multiplier must be at least 1"))
+		}
+		if flap.HysteresisMargin < 0 {
+			errs = append(errs, errors.New("decision.flap_damping.hysteresis_margin must not be negative"))
+		}
+	}
+
+	// Predictor validation
+	if c.Predictor.Enabled {
+		validPredictorTypes := map[string]bool{"linear_trend": true, "pattern_matching": true, "holt_winters": true}
+		if len(c.Predictor.Type) == 0 {
+			errs = append(errs, errors.New("predictor.type must list at least one model"))
+		}
+		for _, predictorType := range c.Predictor.Type {
+			if !validPredictorTypes[predictorType] {
+				errs = append(errs, fmt.Errorf("predictor.type must be one of: linear_trend, pattern_matching, holt_winters (got %q)", predictorType))
+			}
+		}
+		if c.Predictor.ForecastWindow <= 0 {
+			errs = append(errs, errors.New("predictor.forecast_window must be positive"))
+		}
+		if c.Predictor.MinConfidence <= 0 || c.Predictor.MinConfidence > 1 {
+			errs = append(errs, errors.New("predictor.min_confidence must be between 0 and 1"))
+		}
+		if c.Predictor.PatternLookback > 0 && c.Predictor.PatternLookback < 7*24*time.Hour {
+			errs = append(errs, errors.New("predictor.pattern_lookback must cover at least one week"))
+		}
+		if c.Predictor.BackfillInterval < 0 || c.Predictor.BackfillWindow < 0 {
+			errs = append(errs, errors.New("predictor.backfill_interval and predictor.backfill_window must not be negative"))
+		}
+		smoothing := c.Predictor.Smoothing
+		for _, param := range []struct {
+			name  string
+			value float64
+		}{
+			{"alpha", smoothing.Alpha},
+			{"beta", smoothing.Beta},
+			{"gamma", smoothing.Gamma},
+			{"damping", smoothing.Damping},
+		} {
+			if param.value < 0 || param.value > 1 {
+				errs = append(errs, fmt.Errorf("predictor.smoothing.%s must be between 0 and 1", param.name))
+			}
+		}
+		if smoothing.SeasonLength < 0 {
+			errs = append(errs, errors.New("predictor.smoothing.season_length must not be negative"))
+		}
+	}
+
+	// Scheduler validation
+	if c.Scheduler.Enabled && c.Scheduler.Interval < 0 {
+		errs = append(errs, errors.New("scheduler.interval must not be negative"))
+	}
 
 	// API validation
 	if c.API.Port <= 0 || c.API.Port > 65535 {
@@ -0,0 +1,4 @@
+-- 006_pattern_history_variance.sql
+-- Track CPU spread per weekly bucket so pattern forecasts can report confidence
+
+ALTER TABLE pattern_history ADD COLUMN IF NOT EXISTS cpu_stddev FLOAT NOT NULL DEFAULT 0;
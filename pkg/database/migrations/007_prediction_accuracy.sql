@@ -0,0 +1,7 @@
+-- 007_prediction_accuracy.sql
+-- Store forecast intervals and speed up backfilling observed CPU into past predictions
+
+ALTER TABLE predictions ADD COLUMN IF NOT EXISTS lower_bound FLOAT;
+ALTER TABLE predictions ADD COLUMN IF NOT EXISTS upper_bound FLOAT;
+
+CREATE INDEX IF NOT EXISTS idx_predictions_pending ON predictions(forecast_time) WHERE actual_cpu IS NULL;
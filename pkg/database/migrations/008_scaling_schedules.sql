@@ -0,0 +1,31 @@
+-- 008_scaling_schedules.sql
+-- Cron-based windows that temporarily override a cluster's server limits
+
+CREATE TABLE IF NOT EXISTS scaling_schedules (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cluster_id       UUID NOT NULL REFERENCES clusters(id) ON DELETE CASCADE,
+    name             VARCHAR(100) NOT NULL,
+    cron             VARCHAR(100) NOT NULL,
+    timezone         VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    duration_minutes INT NOT NULL,
+    min_servers      INT,
+    max_servers      INT,
+    desired_servers  INT,
+    enabled          BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMPTZ DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT scaling_schedules_name_unique UNIQUE (cluster_id, name),
+    CONSTRAINT scaling_schedules_duration_check CHECK (duration_minutes > 0),
+    CONSTRAINT scaling_schedules_target_check CHECK (
+        min_servers IS NOT NULL OR max_servers IS NOT NULL OR desired_servers IS NOT NULL
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_scaling_schedules_cluster_id ON scaling_schedules(cluster_id);
+CREATE INDEX IF NOT EXISTS idx_scaling_schedules_enabled ON scaling_schedules(enabled) WHERE enabled;
+
+CREATE TRIGGER update_scaling_schedules_updated_at
+    BEFORE UPDATE ON scaling_schedules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,31 @@
+-- 009_scaling_decisions.sql
+-- Every decision the engine makes, including MAINTAIN and cooldown-suppressed ones
+
+CREATE TABLE IF NOT EXISTS scaling_decisions (
+    time            TIMESTAMPTZ NOT NULL,
+    cluster_id      UUID NOT NULL,
+    action          VARCHAR(20) NOT NULL,
+    current_servers INT NOT NULL,
+    target_servers  INT NOT NULL,
+    reason          VARCHAR(100) NOT NULL,
+    rule            VARCHAR(100),
+    outcome         VARCHAR(30) NOT NULL,
+    error           TEXT,
+    is_emergency    BOOLEAN NOT NULL DEFAULT FALSE,
+    cooldown_active BOOLEAN NOT NULL DEFAULT FALSE,
+    prediction_used BOOLEAN NOT NULL DEFAULT FALSE,
+    confidence      FLOAT,
+    trace           JSONB,
+
+    CONSTRAINT scaling_decisions_action_check CHECK (action IN ('SCALE_UP', 'SCALE_DOWN', 'MAINTAIN'))
+);
+
+-- Convert to hypertable
+SELECT create_hypertable('scaling_decisions', 'time', if_not_exists => TRUE);
+
+-- Indexes for decision history queries
+CREATE INDEX IF NOT EXISTS idx_scaling_decisions_cluster_time ON scaling_decisions(cluster_id, time DESC);
+CREATE INDEX IF NOT EXISTS idx_scaling_decisions_cluster_reason ON scaling_decisions(cluster_id, reason, time DESC);
+
+-- Retention policy (30 days, a row is written every collection cycle)
+SELECT add_retention_policy('scaling_decisions', INTERVAL '30 days', if_not_exists => TRUE);
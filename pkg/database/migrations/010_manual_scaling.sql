@@ -0,0 +1,6 @@
+-- 010_manual_scaling.sql
+-- Attribute scaling performed through the manual scale and pin endpoints to the user who asked for it
+
+ALTER TABLE scaling_events ADD COLUMN IF NOT EXISTS initiated_by INT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE scaling_decisions ADD COLUMN IF NOT EXISTS initiated_by INT;
@@ -0,0 +1,38 @@
+-- 011_freeze_windows.sql
+-- One-off or recurring windows that block scale-down, or all scaling, for a cluster
+-- or for every cluster of a user
+
+CREATE TABLE IF NOT EXISTS freeze_windows (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id          INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    cluster_id       UUID REFERENCES clusters(id) ON DELETE CASCADE,
+    name             VARCHAR(100) NOT NULL,
+    scope            VARCHAR(20) NOT NULL DEFAULT 'all',
+    allow_emergency  BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at        TIMESTAMPTZ,
+    ends_at          TIMESTAMPTZ,
+    cron             VARCHAR(100),
+    timezone         VARCHAR(64),
+    duration_minutes INT,
+    reason           TEXT,
+    enabled          BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMPTZ DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT freeze_windows_scope_check CHECK (scope IN ('scale_down', 'all')),
+    CONSTRAINT freeze_windows_kind_check CHECK (
+        (cron IS NULL AND starts_at IS NOT NULL AND ends_at > starts_at) OR
+        (cron IS NOT NULL AND starts_at IS NULL AND ends_at IS NULL AND duration_minutes > 0)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_freeze_windows_user_id ON freeze_windows(user_id);
+CREATE INDEX IF NOT EXISTS idx_freeze_windows_cluster_id ON freeze_windows(cluster_id);
+
+CREATE TRIGGER update_freeze_windows_updated_at
+    BEFORE UPDATE ON freeze_windows
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Name of the freeze window that suppressed a decision
+ALTER TABLE scaling_decisions ADD COLUMN IF NOT EXISTS freeze VARCHAR(100);
@@ -0,0 +1,27 @@
+-- 012_scaling_requests.sql
+-- Scale-ups held back until a user approves them
+
+CREATE TABLE IF NOT EXISTS scaling_requests (
+    id                UUID PRIMARY KEY,
+    cluster_id        UUID NOT NULL REFERENCES clusters(id) ON DELETE CASCADE,
+    action            VARCHAR(20) NOT NULL,
+    current_servers   INT NOT NULL,
+    target_servers    INT NOT NULL,
+    reason            VARCHAR(100) NOT NULL,
+    added_hourly_cost DOUBLE PRECISION,
+    status            VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at        TIMESTAMPTZ NOT NULL,
+    expires_at        TIMESTAMPTZ NOT NULL,
+    resolved_at       TIMESTAMPTZ,
+    resolved_by       INT REFERENCES users(id) ON DELETE SET NULL,
+    outcome           VARCHAR(30),
+    error             TEXT,
+
+    CONSTRAINT scaling_requests_status_check CHECK (status IN ('pending', 'approved', 'rejected', 'expired'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_scaling_requests_cluster_created ON scaling_requests(cluster_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_scaling_requests_pending ON scaling_requests(cluster_id) WHERE status = 'pending';
+
+-- Request a decision waited on, or that its execution carried out
+ALTER TABLE scaling_decisions ADD COLUMN IF NOT EXISTS scaling_request_id UUID;
@@ -0,0 +1,21 @@
+-- 013_custom_metrics.sql
+-- Metrics services export beyond CPU, memory and request load, such as queue depth
+-- or p99 latency. Kept beside metrics_history so its fixed columns stay as they are.
+
+CREATE TABLE IF NOT EXISTS custom_metrics_history (
+    time       TIMESTAMPTZ NOT NULL,
+    cluster_id UUID NOT NULL,
+    server_id  UUID,
+    name       VARCHAR(100) NOT NULL,
+    value      DOUBLE PRECISION NOT NULL
+);
+
+-- Cluster-wide metrics have no server
+COMMENT ON COLUMN custom_metrics_history.server_id IS 'NULL for metrics reported for the whole cluster';
+
+SELECT create_hypertable('custom_metrics_history', 'time', if_not_exists => TRUE);
+
+CREATE INDEX IF NOT EXISTS idx_custom_metrics_cluster_name_time ON custom_metrics_history(cluster_id, name, time DESC);
+
+-- Same retention as metrics_history
+SELECT add_retention_policy('custom_metrics_history', INTERVAL '30 days', if_not_exists => TRUE);
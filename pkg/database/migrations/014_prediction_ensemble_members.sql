@@ -0,0 +1,5 @@
+-- 014_prediction_ensemble_members.sql
+-- Mark the individual forecasts blended into an ensemble prediction, so they can be
+-- scored per model without showing up as predictions of their own
+
+ALTER TABLE predictions ADD COLUMN IF NOT EXISTS ensemble_member BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,13 @@
+-- 015_cluster_pins.sql
+-- Pins set through the pin endpoint, so they outlive a pipeline restart or deploy.
+-- A cluster has at most one pin; pinning again replaces it.
+
+CREATE TABLE IF NOT EXISTS cluster_pins (
+    cluster_id      UUID PRIMARY KEY REFERENCES clusters(id) ON DELETE CASCADE,
+    desired_servers INT NOT NULL,
+    until           TIMESTAMPTZ NOT NULL,
+    pinned_by       INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pinned_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT cluster_pins_servers_check CHECK (desired_servers > 0)
+);
@@ -0,0 +1,124 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CustomMetricPoint is one reading of a service-exported metric. ServerID is nil
+// for metrics reported for the whole cluster.
+type CustomMetricPoint struct {
+	Time      time.Time `json:"time"`
+	ClusterID string    `json:"cluster_id"`
+	ServerID  *string   `json:"server_id,omitempty"`
+	Name      string    `json:"name"`
+	Value     float64   `json:"value"`
+}
+
+// CustomMetricSummary aggregates a custom metric's readings over a bucket
+type CustomMetricSummary struct {
+	Avg         float64 `json:"avg"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	SampleCount int     `json:"sample_count"`
+}
+
+type AggregatedCustomMetricPoint struct {
+	Time time.Time `json:"time"`
+	Name string    `json:"name"`
+	CustomMetricSummary
+}
+
+func (r *MetricsRepository) InsertCustomBatch(ctx context.Context, metrics []CustomMetricPoint) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO custom_metrics_history (time, cluster_id, server_id, name, value)
+		VALUES ($1, $2, $3, $4, $5)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, m := range metrics {
+		if _, err := stmt.ExecContext(ctx, m.Time, m.ClusterID, m.ServerID, m.Name, m.Value); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetCustomAggregated buckets a cluster's custom metrics, newest first. An empty
+// name returns every metric.
+func (r *MetricsRepository) GetCustomAggregated(ctx context.Context, clusterID, name string, from, to time.Time, bucketMinutes int) ([]AggregatedCustomMetricPoint, error) {
+	if bucketMinutes <= 0 {
+		bucketMinutes = 5
+	}
+
+	query := `
+		SELECT
+			time_bucket($4::interval, time) AS bucket,
+			name,
+			AVG(value) AS avg_value,
+			MIN(value) AS min_value,
+			MAX(value) AS max_value,
+			COUNT(*) AS sample_count
+		FROM custom_metrics_history
+		WHERE cluster_id = $1 AND time >= $2 AND time <= $3 AND ($5 = '' OR name = $5)
+		GROUP BY bucket, name
+		ORDER BY bucket DESC, name`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID, from, to, fmt.Sprintf("%d minutes", bucketMinutes), name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var metrics []AggregatedCustomMetricPoint
+	for rows.Next() {
+		var m AggregatedCustomMetricPoint
+		if err := rows.Scan(&m.Time, &m.Name, &m.Avg, &m.Min, &m.Max, &m.SampleCount); err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, m)
+	}
+
+	return metrics, rows.Err()
+}
+
+// getLatestCustom summarises each custom metric reported in the last minute
+func (r *MetricsRepository) getLatestCustom(ctx context.Context, clusterID string) (map[string]CustomMetricSummary, error) {
+	query := `
+		SELECT name, AVG(value), MIN(value), MAX(value), COUNT(*)
+		FROM custom_metrics_history
+		WHERE cluster_id = $1 AND time > NOW() - INTERVAL '1 minute'
+		GROUP BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	custom := make(map[string]CustomMetricSummary)
+	for rows.Next() {
+		var name string
+		var s CustomMetricSummary
+		if err := rows.Scan(&name, &s.Avg, &s.Min, &s.Max, &s.SampleCount); err != nil {
+			return nil, err
+		}
+		custom[name] = s
+	}
+
+	return custom, rows.Err()
+}
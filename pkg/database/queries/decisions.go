@@ -0,0 +1,303 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+type DecisionRepository struct {
+	db *sql.DB
+}
+
+func NewDecisionRepository(db *sql.DB) *DecisionRepository {
+	return &DecisionRepository{db: db}
+}
+
+// DecisionFilter narrows a decision history query. Empty fields match everything.
+type DecisionFilter struct {
+	ClusterID string
+	Action    string
+	Reason    string
+	Outcome   string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+func (r *DecisionRepository) Insert(ctx context.Context, record *models.DecisionRecord) error {
+	var trace []byte
+	if record.Trace != nil {
+		var err error
+		trace, err = json.Marshal(record.Trace)
+		if err != nil {
+			return err
+		}
+	}
+
+	var confidence *float64
+	if record.PredictionUsed {
+		confidence = &record.Confidence
+	}
+
+	query := `
+		INSERT INTO scaling_decisions
+			(time, cluster_id, action, current_servers, target_servers, reason, rule,
+			 outcome, error, is_emergency, cooldown_active, prediction_used, confidence, trace, initiated_by, freeze, scaling_request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		record.Timestamp,
+		record.ClusterID,
+		record.Action,
+		record.CurrentServers,
+		record.TargetServers,
+		record.Reason,
+		nullString(record.Rule),
+		record.Outcome,
+		nullString(record.Error),
+		record.IsEmergency,
+		record.CooldownActive,
+		record.PredictionUsed,
+		confidence,
+		trace,
+		record.InitiatedBy,
+		nullString(record.Freeze),
+		nullString(record.ScalingRequestID),
+	)
+	return err
+}
+
+// List returns the decisions matching filter, newest first
+func (r *DecisionRepository) List(ctx context.Context, filter DecisionFilter) ([]*models.DecisionRecord, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 100
+	}
+
+	conditions := []string{"cluster_id = $1", "time >= $2", "time <= $3"}
+	args := []interface{}{filter.ClusterID, filter.From, filter.To}
+	for _, f := range []struct {
+		column string
+		value  string
+	}{
+		{"action", filter.Action},
+		{"reason", filter.Reason},
+		{"outcome", filter.Outcome},
+	} {
+		if f.value == "" {
+			continue
+		}
+		args = append(args, f.value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", f.column, len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT time, cluster_id, action, current_servers, target_servers, reason, rule,
+			   outcome, error, is_emergency, cooldown_active, prediction_used, confidence, trace, initiated_by, freeze, scaling_request_id
+		FROM scaling_decisions
+		WHERE %s
+		ORDER BY time DESC
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*models.DecisionRecord
+	for rows.Next() {
+		var d models.DecisionRecord
+		var rule, errMsg, freeze, requestID sql.NullString
+		var confidence sql.NullFloat64
+		var trace []byte
+
+		err := rows.Scan(
+			&d.Timestamp, &d.ClusterID, &d.Action, &d.CurrentServers, &d.TargetServers,
+			&d.Reason, &rule, &d.Outcome, &errMsg, &d.IsEmergency, &d.CooldownActive,
+			&d.PredictionUsed, &confidence, &trace, &d.InitiatedBy, &freeze, &requestID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		d.Rule = rule.String
+		d.Error = errMsg.String
+		d.Freeze = freeze.String
+		d.ScalingRequestID = requestID.String
+		d.Confidence = confidence.Float64
+		if len(trace) > 0 {
+			d.Trace = &models.DecisionTrace{}
+			if err := json.Unmarshal(trace, d.Trace); err != nil {
+				return nil, err
+			}
+			d.ProvisioningServers = d.Trace.Inputs.ProvisioningServers
+			d.DrainingServers = d.Trace.Inputs.DrainingServers
+		}
+		records = append(records, &d)
+	}
+
+	return records, rows.Err()
+}
+
+// GetStats counts a cluster's decisions by action, outcome and reason
+func (r *DecisionRepository) GetStats(ctx context.Context, clusterID string, from, to time.Time) (*DecisionStats, error) {
+	query := `
+		SELECT action, outcome, reason, COUNT(*)
+		FROM scaling_decisions
+		WHERE cluster_id = $1 AND time >= $2 AND time <= $3
+		GROUP BY action, outcome, reason`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := &DecisionStats{
+		ClusterID: clusterID,
+		From:      from,
+		To:        to,
+		ByAction:  make(map[string]int),
+		ByOutcome: make(map[string]int),
+		ByReason:  make(map[string]int),
+	}
+	for rows.Next() {
+		var action, outcome, reason string
+		var count int
+		if err := rows.Scan(&action, &outcome, &reason, &count); err != nil {
+			return nil, err
+		}
+		stats.Total += count
+		stats.ByAction[action] += count
+		stats.ByOutcome[outcome] += count
+		stats.ByReason[reason] += count
+	}
+
+	return stats, rows.Err()
+}
+
+// GetObservedReport summarises the scaling actions an observe-mode cluster would have taken
+func (r *DecisionRepository) GetObservedReport(ctx context.Context, clusterID string, from, to time.Time) (*ObservedReport, error) {
+	report := &ObservedReport{
+		ClusterID: clusterID,
+		From:      from,
+		To:        to,
+		ByReason:  make(map[string]int),
+		Timeline:  []ObservedBucket{},
+	}
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE action = 'SCALE_UP') AS scale_up_count,
+			COUNT(*) FILTER (WHERE action = 'SCALE_DOWN') AS scale_down_count,
+			COALESCE(SUM(target_servers - current_servers) FILTER (WHERE action = 'SCALE_UP'), 0) AS servers_added,
+			COALESCE(SUM(current_servers - target_servers) FILTER (WHERE action = 'SCALE_DOWN'), 0) AS servers_removed,
+			MIN(time) AS first_at,
+			MAX(time) AS last_at
+		FROM scaling_decisions
+		WHERE cluster_id = $1 AND time >= $2 AND time <= $3 AND outcome = $4`
+
+	err := r.db.QueryRowContext(ctx, query, clusterID, from, to, models.OutcomeObserved).Scan(
+		&report.ScaleUpCount, &report.ScaleDownCount,
+		&report.ServersAdded, &report.ServersRemoved,
+		&report.FirstAt, &report.LastAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT reason, COUNT(*)
+		FROM scaling_decisions
+		WHERE cluster_id = $1 AND time >= $2 AND time <= $3 AND outcome = $4
+		GROUP BY reason`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID, from, to, models.OutcomeObserved)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reason string
+		var count int
+		if err := rows.Scan(&reason, &count); err != nil {
+			return nil, err
+		}
+		report.ByReason[reason] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT
+			time_bucket(INTERVAL '1 hour', time) AS bucket,
+			COUNT(*) FILTER (WHERE action = 'SCALE_UP') AS scale_up_count,
+			COUNT(*) FILTER (WHERE action = 'SCALE_DOWN') AS scale_down_count
+		FROM scaling_decisions
+		WHERE cluster_id = $1 AND time >= $2 AND time <= $3 AND outcome = $4
+		GROUP BY bucket
+		ORDER BY bucket`
+
+	timeline, err := r.db.QueryContext(ctx, query, clusterID, from, to, models.OutcomeObserved)
+	if err != nil {
+		return nil, err
+	}
+	defer timeline.Close()
+
+	for timeline.Next() {
+		var b ObservedBucket
+		if err := timeline.Scan(&b.Hour, &b.ScaleUpCount, &b.ScaleDownCount); err != nil {
+			return nil, err
+		}
+		report.Timeline = append(report.Timeline, b)
+	}
+
+	return report, timeline.Err()
+}
+
+type ObservedReport struct {
+	ClusterID      string           `json:"cluster_id"`
+	From           time.Time        `json:"from"`
+	To             time.Time        `json:"to"`
+	ScaleUpCount   int              `json:"scale_up_count"`
+	ScaleDownCount int              `json:"scale_down_count"`
+	ServersAdded   int              `json:"servers_added"`
+	ServersRemoved int              `json:"servers_removed"`
+	FirstAt        *time.Time       `json:"first_at,omitempty"`
+	LastAt         *time.Time       `json:"last_at,omitempty"`
+	ByReason       map[string]int   `json:"by_reason"`
+	Timeline       []ObservedBucket `json:"timeline"`
+}
+
+// ObservedBucket counts would-have-been scaling actions in one hour
+type ObservedBucket struct {
+	Hour           time.Time `json:"hour"`
+	ScaleUpCount   int       `json:"scale_up_count"`
+	ScaleDownCount int       `json:"scale_down_count"`
+}
+
+type DecisionStats struct {
+	ClusterID string         `json:"cluster_id"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Total     int            `json:"total"`
+	ByAction  map[string]int `json:"by_action"`
+	ByOutcome map[string]int `json:"by_outcome"`
+	ByReason  map[string]int `json:"by_reason"`
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
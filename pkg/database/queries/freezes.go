@@ -0,0 +1,195 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+var ErrFreezeNotFound = errors.New("freeze window not found")
+
+type FreezeRepository struct {
+	db *sql.DB
+}
+
+func NewFreezeRepository(db *sql.DB) *FreezeRepository {
+	return &FreezeRepository{db: db}
+}
+
+const freezeColumns = `f.id, f.user_id, f.cluster_id, f.name, f.scope, f.allow_emergency,
+		f.starts_at, f.ends_at, f.cron, f.timezone, f.duration_minutes, f.reason,
+		f.enabled, f.created_at, f.updated_at`
+
+// GetByUser returns the user's freeze windows, optionally only those that apply to
+// clusterID: its own plus the user's cluster-wide ones
+func (r *FreezeRepository) GetByUser(ctx context.Context, userID int, clusterID string) ([]*models.FreezeWindow, error) {
+	query := `
+		SELECT ` + freezeColumns + `
+		FROM freeze_windows f
+		WHERE f.user_id = $1 AND ($2 = '' OR f.cluster_id IS NULL OR f.cluster_id::text = $2)
+		ORDER BY f.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanFreezes(rows)
+}
+
+// GetEnabledForCluster returns the enabled freeze windows that apply to a cluster
+// and haven't already ended
+func (r *FreezeRepository) GetEnabledForCluster(ctx context.Context, clusterID string) ([]*models.FreezeWindow, error) {
+	query := `
+		SELECT ` + freezeColumns + `
+		FROM freeze_windows f
+		JOIN clusters c ON f.cluster_id = c.id OR (f.cluster_id IS NULL AND f.user_id = c.user_id)
+		WHERE c.id = $1 AND f.enabled AND (f.ends_at IS NULL OR f.ends_at > NOW())
+		ORDER BY f.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanFreezes(rows)
+}
+
+func (r *FreezeRepository) GetByID(ctx context.Context, userID int, id string) (*models.FreezeWindow, error) {
+	query := `
+		SELECT ` + freezeColumns + `
+		FROM freeze_windows f
+		WHERE f.user_id = $1 AND f.id = $2`
+
+	freeze, err := r.scanFreeze(r.db.QueryRowContext(ctx, query, userID, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrFreezeNotFound
+	}
+	return freeze, err
+}
+
+func (r *FreezeRepository) Create(ctx context.Context, freeze *models.FreezeWindow) error {
+	query := `
+		INSERT INTO freeze_windows (id, user_id, cluster_id, name, scope, allow_emergency,
+			starts_at, ends_at, cron, timezone, duration_minutes, reason, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		freeze.ID,
+		freeze.UserID,
+		freeze.ClusterID,
+		freeze.Name,
+		freeze.Scope,
+		freeze.AllowEmergency,
+		freeze.StartsAt,
+		freeze.EndsAt,
+		nullString(freeze.Cron),
+		nullString(freeze.Timezone),
+		nullInt(freeze.DurationMinutes),
+		nullString(freeze.Reason),
+		freeze.Enabled,
+	).Scan(&freeze.CreatedAt, &freeze.UpdatedAt)
+}
+
+func (r *FreezeRepository) Update(ctx context.Context, freeze *models.FreezeWindow) error {
+	query := `
+		UPDATE freeze_windows
+		SET cluster_id = $3, name = $4, scope = $5, allow_emergency = $6, starts_at = $7,
+			ends_at = $8, cron = $9, timezone = $10, duration_minutes = $11, reason = $12, enabled = $13
+		WHERE user_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		freeze.UserID,
+		freeze.ID,
+		freeze.ClusterID,
+		freeze.Name,
+		freeze.Scope,
+		freeze.AllowEmergency,
+		freeze.StartsAt,
+		freeze.EndsAt,
+		nullString(freeze.Cron),
+		nullString(freeze.Timezone),
+		nullInt(freeze.DurationMinutes),
+		nullString(freeze.Reason),
+		freeze.Enabled,
+	).Scan(&freeze.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrFreezeNotFound
+	}
+	return err
+}
+
+func (r *FreezeRepository) Delete(ctx context.Context, userID int, id string) error {
+	query := `DELETE FROM freeze_windows WHERE user_id = $1 AND id = $2`
+	result, err := r.db.ExecContext(ctx, query, userID, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrFreezeNotFound
+	}
+
+	return nil
+}
+
+func (r *FreezeRepository) scanFreeze(row scheduleScanner) (*models.FreezeWindow, error) {
+	var f models.FreezeWindow
+	var cron, timezone, reason sql.NullString
+	var duration sql.NullInt64
+	err := row.Scan(
+		&f.ID,
+		&f.UserID,
+		&f.ClusterID,
+		&f.Name,
+		&f.Scope,
+		&f.AllowEmergency,
+		&f.StartsAt,
+		&f.EndsAt,
+		&cron,
+		&timezone,
+		&duration,
+		&reason,
+		&f.Enabled,
+		&f.CreatedAt,
+		&f.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Cron = cron.String
+	f.Timezone = timezone.String
+	f.DurationMinutes = int(duration.Int64)
+	f.Reason = reason.String
+	return &f, nil
+}
+
+func (r *FreezeRepository) scanFreezes(rows *sql.Rows) ([]*models.FreezeWindow, error) {
+	var freezes []*models.FreezeWindow
+	for rows.Next() {
+		freeze, err := r.scanFreeze(rows)
+		if err != nil {
+			return nil, err
+		}
+		freezes = append(freezes, freeze)
+	}
+	return freezes, rows.Err()
+}
+
+func nullInt(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
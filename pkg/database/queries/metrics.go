@@ -3,6 +3,7 @@ package queries
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 )
 
@@ -32,8 +33,25 @@ type AggregatedMetricPoint struct {
 	MaxCPU      float64   `json:"max_cpu"`
 	MinCPU      float64   `json:"min_cpu"`
 	SampleCount int       `json:"sample_count"`
+
+	// CPU percentiles across every reading in the bucket; nil once raw metrics
+	// have expired
+	P50CPU *float64 `json:"p50_cpu,omitempty"`
+	P90CPU *float64 `json:"p90_cpu,omitempty"`
+	P95CPU *float64 `json:"p95_cpu,omitempty"`
+	P99CPU *float64 `json:"p99_cpu,omitempty"`
+
+	// Custom metrics by name; only filled in for the latest metrics
+	Custom map[string]CustomMetricSummary `json:"custom,omitempty"`
 }
 
+// cpuPercentileColumns selects CPU percentiles over the grouped rows of metrics_history
+const cpuPercentileColumns = `
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY cpu_usage) AS p50_cpu,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY cpu_usage) AS p90_cpu,
+			percentile_cont(0.95) WITHIN GROUP (ORDER BY cpu_usage) AS p95_cpu,
+			percentile_cont(0.99) WITHIN GROUP (ORDER BY cpu_usage) AS p99_cpu`
+
 func (r *MetricsRepository) GetRaw(ctx context.Context, clusterID string, from, to time.Time, limit int) ([]MetricPoint, error) {
 	if limit <= 0 {
 		limit = 100
@@ -72,20 +90,20 @@ func (r *MetricsRepository) GetAggregated(ctx context.Context, clusterID string,
 
 	query := `
 		SELECT 
-			time_bucket($4, time) AS bucket,
+			time_bucket($4::interval, time) AS bucket,
 			cluster_id,
 			AVG(cpu_usage) AS avg_cpu,
 			AVG(memory_usage) AS avg_memory,
 			AVG(request_load) AS avg_load,
 			MAX(cpu_usage) AS max_cpu,
 			MIN(cpu_usage) AS min_cpu,
-			COUNT(*) AS sample_count
+			COUNT(*) AS sample_count,` + cpuPercentileColumns + `
 		FROM metrics_history
 		WHERE cluster_id = $1 AND time >= $2 AND time <= $3
 		GROUP BY bucket, cluster_id
 		ORDER BY bucket DESC`
 
-	interval := time.Duration(bucketMinutes) * time.Minute
+	interval := fmt.Sprintf("%d minutes", bucketMinutes)
 	rows, err := r.db.QueryContext(ctx, query, clusterID, from, to, interval)
 	if err != nil {
 		return nil, err
@@ -95,7 +113,8 @@ func (r *MetricsRepository) GetAggregated(ctx context.Context, clusterID string,
 	var metrics []AggregatedMetricPoint
 	for rows.Next() {
 		var m AggregatedMetricPoint
-		err := rows.Scan(&m.Time, &m.ClusterID, &m.AvgCPU, &m.AvgMemory, &m.AvgLoad, &m.MaxCPU, &m.MinCPU, &m.SampleCount)
+		err := rows.Scan(&m.Time, &m.ClusterID, &m.AvgCPU, &m.AvgMemory, &m.AvgLoad, &m.MaxCPU, &m.MinCPU, &m.SampleCount,
+			&m.P50CPU, &m.P90CPU, &m.P95CPU, &m.P99CPU)
 		if err != nil {
 			return nil, err
 		}
@@ -105,20 +124,34 @@ func (r *MetricsRepository) GetAggregated(ctx context.Context, clusterID string,
 	return metrics, rows.Err()
 }
 
+// GetHourly reads the hourly continuous aggregate. Percentiles can't be rolled up
+// from it, so they're computed from raw metrics for the hours that still have them.
 func (r *MetricsRepository) GetHourly(ctx context.Context, clusterID string, from, to time.Time) ([]AggregatedMetricPoint, error) {
 	query := `
+		WITH percentiles AS (
+			SELECT
+				time_bucket('1 hour', time) AS hour,` + cpuPercentileColumns + `
+			FROM metrics_history
+			WHERE cluster_id = $1 AND time >= $2 AND time < $3::timestamptz + INTERVAL '1 hour'
+			GROUP BY 1
+		)
 		SELECT 
-			hour AS time,
-			cluster_id,
-			avg_cpu,
-			avg_memory,
-			avg_load,
-			max_cpu,
-			min_cpu,
-			sample_count
-		FROM metrics_hourly
-		WHERE cluster_id = $1 AND hour >= $2 AND hour <= $3
-		ORDER BY hour DESC`
+			h.hour AS time,
+			h.cluster_id,
+			h.avg_cpu,
+			h.avg_memory,
+			h.avg_load,
+			h.max_cpu,
+			h.min_cpu,
+			h.sample_count,
+			p.p50_cpu,
+			p.p90_cpu,
+			p.p95_cpu,
+			p.p99_cpu
+		FROM metrics_hourly h
+		LEFT JOIN percentiles p ON p.hour = h.hour
+		WHERE h.cluster_id = $1 AND h.hour >= $2 AND h.hour <= $3
+		ORDER BY h.hour DESC`
 
 	rows, err := r.db.QueryContext(ctx, query, clusterID, from, to)
 	if err != nil {
@@ -129,7 +162,8 @@ func (r *MetricsRepository) GetHourly(ctx context.Context, clusterID string, fro
 	var metrics []AggregatedMetricPoint
 	for rows.Next() {
 		var m AggregatedMetricPoint
-		err := rows.Scan(&m.Time, &m.ClusterID, &m.AvgCPU, &m.AvgMemory, &m.AvgLoad, &m.MaxCPU, &m.MinCPU, &m.SampleCount)
+		err := rows.Scan(&m.Time, &m.ClusterID, &m.AvgCPU, &m.AvgMemory, &m.AvgLoad, &m.MaxCPU, &m.MinCPU, &m.SampleCount,
+			&m.P50CPU, &m.P90CPU, &m.P95CPU, &m.P99CPU)
 		if err != nil {
 			return nil, err
 		}
@@ -149,7 +183,7 @@ func (r *MetricsRepository) GetLatest(ctx context.Context, clusterID string) (*A
 			AVG(request_load) AS avg_load,
 			MAX(cpu_usage) AS max_cpu,
 			MIN(cpu_usage) AS min_cpu,
-			COUNT(*) AS sample_count
+			COUNT(*) AS sample_count,` + cpuPercentileColumns + `
 		FROM metrics_history
 		WHERE cluster_id = $1 AND time > NOW() - INTERVAL '1 minute'
 		GROUP BY cluster_id`
@@ -157,6 +191,7 @@ func (r *MetricsRepository) GetLatest(ctx context.Context, clusterID string) (*A
 	var m AggregatedMetricPoint
 	err := r.db.QueryRowContext(ctx, query, clusterID).Scan(
 		&m.Time, &m.ClusterID, &m.AvgCPU, &m.AvgMemory, &m.AvgLoad, &m.MaxCPU, &m.MinCPU, &m.SampleCount,
+		&m.P50CPU, &m.P90CPU, &m.P95CPU, &m.P99CPU,
 	)
 
 	if err == sql.ErrNoRows {
@@ -166,6 +201,11 @@ func (r *MetricsRepository) GetLatest(ctx context.Context, clusterID string) (*A
 		return nil, err
 	}
 
+	m.Custom, err = r.getLatestCustom(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &m, nil
 }
 
@@ -0,0 +1,117 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+type PatternRepository struct {
+	db *sql.DB
+}
+
+func NewPatternRepository(db *sql.DB) *PatternRepository {
+	return &PatternRepository{db: db}
+}
+
+// GetBucket returns the weekly pattern for a day of week (0 = Sunday) and hour, in UTC
+func (r *PatternRepository) GetBucket(ctx context.Context, clusterID string, dayOfWeek, hourOfDay int) (*models.PatternRecord, error) {
+	query := `
+		SELECT cluster_id, day_of_week, hour_of_day, avg_cpu, avg_memory, avg_load,
+			   cpu_stddev, sample_count, last_updated
+		FROM pattern_history
+		WHERE cluster_id = $1 AND day_of_week = $2 AND hour_of_day = $3`
+
+	var p models.PatternRecord
+	err := r.db.QueryRowContext(ctx, query, clusterID, dayOfWeek, hourOfDay).Scan(
+		&p.ClusterID, &p.DayOfWeek, &p.HourOfDay, &p.AvgCPU, &p.AvgMemory, &p.AvgLoad,
+		&p.CPUStdDev, &p.SampleCount, &p.LastUpdated,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *PatternRepository) GetByCluster(ctx context.Context, clusterID string) ([]models.PatternRecord, error) {
+	query := `
+		SELECT cluster_id, day_of_week, hour_of_day, avg_cpu, avg_memory, avg_load,
+			   cpu_stddev, sample_count, last_updated
+		FROM pattern_history
+		WHERE cluster_id = $1
+		ORDER BY day_of_week, hour_of_day`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var patterns []models.PatternRecord
+	for rows.Next() {
+		var p models.PatternRecord
+		err := rows.Scan(
+			&p.ClusterID, &p.DayOfWeek, &p.HourOfDay, &p.AvgCPU, &p.AvgMemory, &p.AvgLoad,
+			&p.CPUStdDev, &p.SampleCount, &p.LastUpdated,
+		)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+
+	return patterns, rows.Err()
+}
+
+// Refresh rebuilds the weekly buckets from metrics_history between from and to.
+// Each bucket averages the hourly cluster means that fall into it, so sample_count
+// is the number of weeks observed for that slot.
+func (r *PatternRepository) Refresh(ctx context.Context, from, to time.Time) (int64, error) {
+	query := `
+		INSERT INTO pattern_history
+			(cluster_id, day_of_week, hour_of_day, avg_cpu, avg_memory, avg_load,
+			 cpu_stddev, sample_count, last_updated)
+		SELECT
+			cluster_id,
+			EXTRACT(DOW FROM bucket AT TIME ZONE 'UTC')::INT AS day_of_week,
+			EXTRACT(HOUR FROM bucket AT TIME ZONE 'UTC')::INT AS hour_of_day,
+			AVG(avg_cpu),
+			AVG(avg_memory),
+			AVG(avg_load),
+			COALESCE(STDDEV_SAMP(avg_cpu), 0),
+			COUNT(*),
+			NOW()
+		FROM (
+			SELECT
+				time_bucket('1 hour', time) AS bucket,
+				cluster_id,
+				AVG(cpu_usage) AS avg_cpu,
+				AVG(memory_usage) AS avg_memory,
+				AVG(request_load) AS avg_load
+			FROM metrics_history
+			WHERE time >= $1 AND time < $2
+			GROUP BY bucket, cluster_id
+		) hourly
+		GROUP BY cluster_id, day_of_week, hour_of_day
+		ON CONFLICT (cluster_id, day_of_week, hour_of_day) DO UPDATE SET
+			avg_cpu = EXCLUDED.avg_cpu,
+			avg_memory = EXCLUDED.avg_memory,
+			avg_load = EXCLUDED.avg_load,
+			cpu_stddev = EXCLUDED.cpu_stddev,
+			sample_count = EXCLUDED.sample_count,
+			last_updated = EXCLUDED.last_updated`
+
+	result, err := r.db.ExecContext(ctx, query, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
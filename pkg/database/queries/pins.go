@@ -0,0 +1,54 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+type PinRepository struct {
+	db *sql.DB
+}
+
+func NewPinRepository(db *sql.DB) *PinRepository {
+	return &PinRepository{db: db}
+}
+
+// Save stores the cluster's pin, replacing any earlier one
+func (r *PinRepository) Save(ctx context.Context, clusterID string, pin models.ClusterPin) error {
+	query := `
+		INSERT INTO cluster_pins (cluster_id, desired_servers, until, pinned_by, pinned_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (cluster_id) DO UPDATE SET
+			desired_servers = EXCLUDED.desired_servers,
+			until = EXCLUDED.until,
+			pinned_by = EXCLUDED.pinned_by,
+			pinned_at = EXCLUDED.pinned_at`
+
+	_, err := r.db.ExecContext(ctx, query, clusterID, pin.DesiredServers, pin.Until, pin.PinnedBy, pin.PinnedAt)
+	return err
+}
+
+func (r *PinRepository) Delete(ctx context.Context, clusterID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM cluster_pins WHERE cluster_id = $1`, clusterID)
+	return err
+}
+
+// GetActive returns the cluster's pin if it hasn't expired, nil otherwise
+func (r *PinRepository) GetActive(ctx context.Context, clusterID string) (*models.ClusterPin, error) {
+	query := `
+		SELECT desired_servers, until, pinned_by, pinned_at
+		FROM cluster_pins
+		WHERE cluster_id = $1 AND until > NOW()`
+
+	var pin models.ClusterPin
+	err := r.db.QueryRowContext(ctx, query, clusterID).Scan(&pin.DesiredServers, &pin.Until, &pin.PinnedBy, &pin.PinnedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
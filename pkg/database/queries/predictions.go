@@ -0,0 +1,131 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+type PredictionRepository struct {
+	db *sql.DB
+}
+
+func NewPredictionRepository(db *sql.DB) *PredictionRepository {
+	return &PredictionRepository{db: db}
+}
+
+// PredictionAccuracy summarises how well one model's forecasts matched observed CPU
+type PredictionAccuracy struct {
+	ModelVersion string  `json:"model_version"`
+	Samples      int     `json:"samples"`
+	MAE          float64 `json:"mae"`
+	MAPE         float64 `json:"mape"`
+	HitRate      float64 `json:"hit_rate"`
+	Tolerance    float64 `json:"tolerance"`
+}
+
+// GetByCluster lists a cluster's predictions, leaving out the member forecasts
+// stored alongside each ensemble prediction
+func (r *PredictionRepository) GetByCluster(ctx context.Context, clusterID string, from, to time.Time, limit int) ([]models.Prediction, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	query := `
+		SELECT id, created_at, cluster_id, forecast_time, predicted_cpu, lower_bound, upper_bound,
+			   actual_cpu, COALESCE(confidence, 0), COALESCE(model_version, '')
+		FROM predictions
+		WHERE cluster_id = $1 AND created_at >= $2 AND created_at <= $3 AND NOT ensemble_member
+		ORDER BY created_at DESC
+		LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var predictions []models.Prediction
+	for rows.Next() {
+		var p models.Prediction
+		var lowerBound, upperBound sql.NullFloat64
+		err := rows.Scan(
+			&p.ID, &p.CreatedAt, &p.ClusterID, &p.ForecastTime, &p.PredictedCPU,
+			&lowerBound, &upperBound, &p.ActualCPU, &p.Confidence, &p.ModelVersion,
+		)
+		if err != nil {
+			return nil, err
+		}
+		p.LowerBound = lowerBound.Float64
+		p.UpperBound = upperBound.Float64
+		predictions = append(predictions, p)
+	}
+
+	return predictions, rows.Err()
+}
+
+// BackfillActuals fills actual_cpu for predictions whose forecast time has passed,
+// using the cluster's average CPU within window either side of the forecast time.
+// Predictions with no observed metrics in that window are left untouched and
+// given up on after a day so they aren't rescanned forever.
+func (r *PredictionRepository) BackfillActuals(ctx context.Context, window time.Duration, before time.Time) (int64, error) {
+	query := `
+		UPDATE predictions p
+		SET actual_cpu = observed.avg_cpu
+		FROM (
+			SELECT pending.id, pending.created_at, AVG(m.cpu_usage) AS avg_cpu
+			FROM predictions pending
+			JOIN metrics_history m
+				ON m.cluster_id = pending.cluster_id
+				AND m.time >= pending.forecast_time - make_interval(secs => $1)
+				AND m.time <= pending.forecast_time + make_interval(secs => $1)
+			WHERE pending.actual_cpu IS NULL
+				AND pending.forecast_time <= $2
+				AND pending.forecast_time > $2 - INTERVAL '1 day'
+			GROUP BY pending.id, pending.created_at
+		) observed
+		WHERE p.id = observed.id AND p.created_at = observed.created_at`
+
+	result, err := r.db.ExecContext(ctx, query, window.Seconds(), before.Add(-window))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// GetAccuracy returns MAE, MAPE and the share of forecasts within tolerance
+// percentage points of the observed CPU, grouped by model version
+func (r *PredictionRepository) GetAccuracy(ctx context.Context, clusterID string, from, to time.Time, tolerance float64) ([]PredictionAccuracy, error) {
+	query := `
+		SELECT
+			COALESCE(model_version, 'unknown'),
+			COUNT(*),
+			AVG(ABS(predicted_cpu - actual_cpu)),
+			COALESCE(AVG(ABS(predicted_cpu - actual_cpu) / NULLIF(actual_cpu, 0)) * 100, 0),
+			AVG(CASE WHEN ABS(predicted_cpu - actual_cpu) <= $4 THEN 1.0 ELSE 0.0 END)
+		FROM predictions
+		WHERE cluster_id = $1 AND forecast_time >= $2 AND forecast_time <= $3
+			AND actual_cpu IS NOT NULL
+		GROUP BY COALESCE(model_version, 'unknown')
+		ORDER BY 1`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID, from, to, tolerance)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accuracy []PredictionAccuracy
+	for rows.Next() {
+		a := PredictionAccuracy{Tolerance: tolerance}
+		if err := rows.Scan(&a.ModelVersion, &a.Samples, &a.MAE, &a.MAPE, &a.HitRate); err != nil {
+			return nil, err
+		}
+		accuracy = append(accuracy, a)
+	}
+
+	return accuracy, rows.Err()
+}
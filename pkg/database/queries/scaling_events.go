@@ -27,6 +27,7 @@ type ScalingEventRecord struct {
 	PredictionUsed bool       `json:"prediction_used"`
 	Confidence     *float64   `json:"confidence,omitempty"`
 	Status         string     `json:"status"`
+	InitiatedBy    *int       `json:"initiated_by,omitempty"`
 }
 
 func (r *ScalingEventRepository) GetByCluster(ctx context.Context, clusterID string, from, to time.Time, limit int) ([]ScalingEventRecord, error) {
@@ -36,7 +37,7 @@ func (r *ScalingEventRepository) GetByCluster(ctx context.Context, clusterID str
 
 	query := `
 		SELECT id, cluster_id, timestamp, action, servers_before, servers_after, 
-			   trigger_reason, prediction_used, confidence, status
+			   trigger_reason, prediction_used, confidence, status, initiated_by
 		FROM scaling_events
 		WHERE cluster_id = $1 AND timestamp >= $2 AND timestamp <= $3
 		ORDER BY timestamp DESC
@@ -54,7 +55,7 @@ func (r *ScalingEventRepository) GetByCluster(ctx context.Context, clusterID str
 		err := rows.Scan(
 			&e.ID, &e.ClusterID, &e.Timestamp, &e.Action,
 			&e.ServersBefore, &e.ServersAfter, &e.TriggerReason,
-			&e.PredictionUsed, &e.Confidence, &e.Status,
+			&e.PredictionUsed, &e.Confidence, &e.Status, &e.InitiatedBy,
 		)
 		if err != nil {
 			return nil, err
@@ -72,7 +73,7 @@ func (r *ScalingEventRepository) GetRecent(ctx context.Context, limit int) ([]Sc
 
 	query := `
 		SELECT id, cluster_id, timestamp, action, servers_before, servers_after, 
-			   trigger_reason, prediction_used, confidence, status
+			   trigger_reason, prediction_used, confidence, status, initiated_by
 		FROM scaling_events
 		ORDER BY timestamp DESC
 		LIMIT $1`
@@ -89,7 +90,7 @@ func (r *ScalingEventRepository) GetRecent(ctx context.Context, limit int) ([]Sc
 		err := rows.Scan(
 			&e.ID, &e.ClusterID, &e.Timestamp, &e.Action,
 			&e.ServersBefore, &e.ServersAfter, &e.TriggerReason,
-			&e.PredictionUsed, &e.Confidence, &e.Status,
+			&e.PredictionUsed, &e.Confidence, &e.Status, &e.InitiatedBy,
 		)
 		if err != nil {
 			return nil, err
@@ -108,7 +109,7 @@ func (r *ScalingEventRepository) GetRecentByUserID(ctx context.Context, userID i
 
 	query := `
 		SELECT se.id, se.cluster_id::text, se.timestamp, se.action, se.servers_before, se.servers_after, 
-			   se.trigger_reason, se.prediction_used, se.confidence, se.status
+			   se.trigger_reason, se.prediction_used, se.confidence, se.status, se.initiated_by
 		FROM scaling_events se
 		INNER JOIN clusters c ON se.cluster_id = c.id
 		WHERE c.user_id = $1
@@ -127,7 +128,7 @@ func (r *ScalingEventRepository) GetRecentByUserID(ctx context.Context, userID i
 		err := rows.Scan(
 			&e.ID, &e.ClusterID, &e.Timestamp, &e.Action,
 			&e.ServersBefore, &e.ServersAfter, &e.TriggerReason,
-			&e.PredictionUsed, &e.Confidence, &e.Status,
+			&e.PredictionUsed, &e.Confidence, &e.Status, &e.InitiatedBy,
 		)
 		if err != nil {
 			return nil, err
@@ -170,8 +171,8 @@ func (r *ScalingEventRepository) Insert(ctx context.Context, event *models.Scali
 	query := `
 		INSERT INTO scaling_events 
 			(cluster_id, timestamp, action, servers_before, servers_after, 
-			 trigger_reason, prediction_used, confidence, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			 trigger_reason, prediction_used, confidence, status, initiated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id`
 
 	return r.db.QueryRowContext(ctx, query,
@@ -184,6 +185,7 @@ func (r *ScalingEventRepository) Insert(ctx context.Context, event *models.Scali
 		event.PredictionUsed,
 		event.Confidence,
 		event.Status,
+		event.InitiatedBy,
 	).Scan(&event.ID)
 }
 
@@ -0,0 +1,175 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+var ErrScalingRequestNotFound = errors.New("scaling request not found")
+
+type ScalingRequestRepository struct {
+	db *sql.DB
+}
+
+func NewScalingRequestRepository(db *sql.DB) *ScalingRequestRepository {
+	return &ScalingRequestRepository{db: db}
+}
+
+// ScalingRequestFilter narrows a scaling request query to one user's clusters.
+// Empty fields match everything.
+type ScalingRequestFilter struct {
+	UserID    int
+	ClusterID string
+	Status    string
+	Limit     int
+}
+
+const scalingRequestColumns = `r.id, r.cluster_id, r.action, r.current_servers, r.target_servers, r.reason,
+		r.added_hourly_cost, r.status, r.created_at, r.expires_at, r.resolved_at, r.resolved_by,
+		r.outcome, r.error`
+
+// Save inserts the request or, once it exists, records how it was resolved
+func (r *ScalingRequestRepository) Save(ctx context.Context, request *models.ScalingRequest) error {
+	query := `
+		INSERT INTO scaling_requests
+			(id, cluster_id, action, current_servers, target_servers, reason, added_hourly_cost,
+			 status, created_at, expires_at, resolved_at, resolved_by, outcome, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			resolved_at = EXCLUDED.resolved_at,
+			resolved_by = EXCLUDED.resolved_by,
+			outcome = EXCLUDED.outcome,
+			error = EXCLUDED.error`
+
+	var cost *float64
+	if request.AddedHourlyCost > 0 {
+		cost = &request.AddedHourlyCost
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		request.ID,
+		request.ClusterID,
+		request.Action,
+		request.CurrentServers,
+		request.TargetServers,
+		request.Reason,
+		cost,
+		request.Status,
+		request.CreatedAt,
+		request.ExpiresAt,
+		request.ResolvedAt,
+		request.ResolvedBy,
+		nullString(string(request.Outcome)),
+		nullString(request.Error),
+	)
+	return err
+}
+
+func (r *ScalingRequestRepository) GetByID(ctx context.Context, id string) (*models.ScalingRequest, error) {
+	query := `
+		SELECT ` + scalingRequestColumns + `
+		FROM scaling_requests r
+		WHERE r.id = $1`
+
+	request, err := r.scanRequest(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrScalingRequestNotFound
+	}
+	return request, err
+}
+
+// List returns the requests on the user's clusters matching filter, newest first
+func (r *ScalingRequestRepository) List(ctx context.Context, filter ScalingRequestFilter) ([]*models.ScalingRequest, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 100
+	}
+
+	conditions := []string{"c.user_id = $1"}
+	args := []interface{}{filter.UserID}
+	for _, f := range []struct {
+		column string
+		value  string
+	}{
+		{"r.cluster_id::text", filter.ClusterID},
+		{"r.status", filter.Status},
+	} {
+		if f.value == "" {
+			continue
+		}
+		args = append(args, f.value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", f.column, len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT `+scalingRequestColumns+`
+		FROM scaling_requests r
+		JOIN clusters c ON c.id = r.cluster_id
+		WHERE %s
+		ORDER BY r.created_at DESC
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*models.ScalingRequest
+	for rows.Next() {
+		request, err := r.scanRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, rows.Err()
+}
+
+// ExpirePending closes the cluster's pending requests. Pending requests live in the
+// cluster's pipeline, so any left over from before it started can no longer be approved.
+func (r *ScalingRequestRepository) ExpirePending(ctx context.Context, clusterID string) error {
+	query := `
+		UPDATE scaling_requests
+		SET status = 'expired', resolved_at = NOW()
+		WHERE cluster_id = $1 AND status = 'pending'`
+
+	_, err := r.db.ExecContext(ctx, query, clusterID)
+	return err
+}
+
+func (r *ScalingRequestRepository) scanRequest(row scheduleScanner) (*models.ScalingRequest, error) {
+	var req models.ScalingRequest
+	var cost sql.NullFloat64
+	var outcome, errMsg sql.NullString
+	err := row.Scan(
+		&req.ID,
+		&req.ClusterID,
+		&req.Action,
+		&req.CurrentServers,
+		&req.TargetServers,
+		&req.Reason,
+		&cost,
+		&req.Status,
+		&req.CreatedAt,
+		&req.ExpiresAt,
+		&req.ResolvedAt,
+		&req.ResolvedBy,
+		&outcome,
+		&errMsg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddedHourlyCost = cost.Float64
+	req.Outcome = models.DecisionOutcome(outcome.String)
+	req.Error = errMsg.String
+	return &req, nil
+}
@@ -0,0 +1,173 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+type ScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) *ScheduleRepository {
+	return &ScheduleRepository{db: db}
+}
+
+const scheduleColumns = `id, cluster_id, name, cron, timezone, duration_minutes,
+		min_servers, max_servers, desired_servers, enabled, created_at, updated_at`
+
+func (r *ScheduleRepository) GetByCluster(ctx context.Context, clusterID string) ([]*models.ScalingSchedule, error) {
+	query := `
+		SELECT ` + scheduleColumns + `
+		FROM scaling_schedules
+		WHERE cluster_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSchedules(rows)
+}
+
+// GetEnabled returns the enabled schedules of every active cluster
+func (r *ScheduleRepository) GetEnabled(ctx context.Context) ([]*models.ScalingSchedule, error) {
+	query := `
+		SELECT s.id, s.cluster_id, s.name, s.cron, s.timezone, s.duration_minutes,
+			   s.min_servers, s.max_servers, s.desired_servers, s.enabled, s.created_at, s.updated_at
+		FROM scaling_schedules s
+		JOIN clusters c ON c.id = s.cluster_id
+		WHERE s.enabled AND c.status = 'active'
+		ORDER BY s.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSchedules(rows)
+}
+
+func (r *ScheduleRepository) GetByID(ctx context.Context, clusterID, id string) (*models.ScalingSchedule, error) {
+	query := `
+		SELECT ` + scheduleColumns + `
+		FROM scaling_schedules
+		WHERE cluster_id = $1 AND id = $2`
+
+	schedule, err := r.scanSchedule(r.db.QueryRowContext(ctx, query, clusterID, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule, err
+}
+
+func (r *ScheduleRepository) Create(ctx context.Context, schedule *models.ScalingSchedule) error {
+	query := `
+		INSERT INTO scaling_schedules (id, cluster_id, name, cron, timezone, duration_minutes,
+			min_servers, max_servers, desired_servers, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		schedule.ID,
+		schedule.ClusterID,
+		schedule.Name,
+		schedule.Cron,
+		schedule.Timezone,
+		schedule.DurationMinutes,
+		schedule.MinServers,
+		schedule.MaxServers,
+		schedule.DesiredServers,
+		schedule.Enabled,
+	).Scan(&schedule.CreatedAt, &schedule.UpdatedAt)
+}
+
+func (r *ScheduleRepository) Update(ctx context.Context, schedule *models.ScalingSchedule) error {
+	query := `
+		UPDATE scaling_schedules
+		SET name = $3, cron = $4, timezone = $5, duration_minutes = $6,
+			min_servers = $7, max_servers = $8, desired_servers = $9, enabled = $10
+		WHERE cluster_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		schedule.ClusterID,
+		schedule.ID,
+		schedule.Name,
+		schedule.Cron,
+		schedule.Timezone,
+		schedule.DurationMinutes,
+		schedule.MinServers,
+		schedule.MaxServers,
+		schedule.DesiredServers,
+		schedule.Enabled,
+	).Scan(&schedule.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrScheduleNotFound
+	}
+	return err
+}
+
+func (r *ScheduleRepository) Delete(ctx context.Context, clusterID, id string) error {
+	query := `DELETE FROM scaling_schedules WHERE cluster_id = $1 AND id = $2`
+	result, err := r.db.ExecContext(ctx, query, clusterID, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrScheduleNotFound
+	}
+
+	return nil
+}
+
+type scheduleScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *ScheduleRepository) scanSchedule(row scheduleScanner) (*models.ScalingSchedule, error) {
+	var s models.ScalingSchedule
+	err := row.Scan(
+		&s.ID,
+		&s.ClusterID,
+		&s.Name,
+		&s.Cron,
+		&s.Timezone,
+		&s.DurationMinutes,
+		&s.MinServers,
+		&s.MaxServers,
+		&s.DesiredServers,
+		&s.Enabled,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *ScheduleRepository) scanSchedules(rows *sql.Rows) ([]*models.ScalingSchedule, error) {
+	var schedules []*models.ScalingSchedule
+	for rows.Next() {
+		schedule, err := r.scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
@@ -24,15 +24,43 @@ type AnalyzedMetrics struct {
 	Timestamp       time.Time       `json:"timestamp"`
 	AvgCPU          float64         `json:"avg_cpu"`
 	AvgMemory       float64         `json:"avg_memory"`
+	AvgLoad         float64         `json:"avg_load"`
+	TotalLoad       float64         `json:"total_load"`
 	ServerCount     int             `json:"server_count"`
 	CPUStatus       ThresholdStatus `json:"cpu_status"`
 	MemoryStatus    ThresholdStatus `json:"memory_status"`
 	Trend           Trend           `json:"trend"`
 	HasSpike        bool            `json:"has_spike"`
 	SpikePercent    float64         `json:"spike_percent,omitempty"`
+	IsAnomaly       bool            `json:"is_anomaly"`
+	AnomalyScore    float64         `json:"anomaly_score,omitempty"`
+	AnomalyBaseline float64         `json:"anomaly_baseline,omitempty"`
+	AnomalyDetector string          `json:"anomaly_detector,omitempty"`
 	Recommendation  string          `json:"recommendation,omitempty"`
 	SustainedHighAt *time.Time      `json:"sustained_high_at,omitempty"`
 	SustainedLowAt  *time.Time      `json:"sustained_low_at,omitempty"`
+
+	SustainedMemoryHighAt *time.Time `json:"sustained_memory_high_at,omitempty"`
+	SustainedMemoryLowAt  *time.Time `json:"sustained_memory_low_at,omitempty"`
+
+	// CPU spread across servers, or across every reading in the analyzer's percentile window
+	MaxCPU float64 `json:"max_cpu"`
+	CPUPercentiles
+
+	// CPUStatistic picks which CPU figure ScalingCPU holds; empty means the average
+	CPUStatistic CPUStatistic `json:"cpu_statistic,omitempty"`
+	ScalingCPU   float64      `json:"scaling_cpu,omitempty"`
+
+	// Custom metrics by name, summarised across servers
+	Custom map[string]CustomMetricValue `json:"custom,omitempty"`
+}
+
+// SignalCPU returns the CPU figure thresholds and target tracking act on
+func (a *AnalyzedMetrics) SignalCPU() float64 {
+	if a.CPUStatistic == "" || a.CPUStatistic == CPUStatisticAvg {
+		return a.AvgCPU
+	}
+	return a.ScalingCPU
 }
 
 func (a *AnalyzedMetrics) IsCritical() bool {
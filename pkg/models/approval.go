@@ -0,0 +1,122 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ApprovalTimeoutAction says what happens to a request nobody approved or rejected in time
+type ApprovalTimeoutAction string
+
+const (
+	ApprovalTimeoutExpire  ApprovalTimeoutAction = "expire"
+	ApprovalTimeoutApprove ApprovalTimeoutAction = "approve"
+)
+
+// ApprovalPolicy makes large scale-ups wait for a human. A scale-up needs approval
+// when it adds more than MaxDelta servers, or more than MaxHourlyCost per hour at
+// ServerHourlyCost per server. Zero limits are not checked.
+type ApprovalPolicy struct {
+	MaxDelta         int                   `json:"max_delta,omitempty"`
+	ServerHourlyCost float64               `json:"server_hourly_cost,omitempty"`
+	MaxHourlyCost    float64               `json:"max_hourly_cost,omitempty"`
+	TimeoutSeconds   int                   `json:"timeout_seconds,omitempty"`
+	OnTimeout        ApprovalTimeoutAction `json:"on_timeout,omitempty"`
+}
+
+func (p *ApprovalPolicy) Validate() error {
+	if p.MaxDelta < 0 || p.ServerHourlyCost < 0 || p.MaxHourlyCost < 0 || p.TimeoutSeconds < 0 {
+		return errors.New("approval limits must not be negative")
+	}
+	if p.MaxDelta == 0 && p.MaxHourlyCost == 0 {
+		return errors.New("approval needs max_delta or max_hourly_cost")
+	}
+	if p.MaxHourlyCost > 0 && p.ServerHourlyCost == 0 {
+		return errors.New("approval max_hourly_cost requires server_hourly_cost")
+	}
+	switch p.OnTimeout {
+	case "", ApprovalTimeoutExpire, ApprovalTimeoutApprove:
+	default:
+		return fmt.Errorf("approval on_timeout must be one of: %s, %s", ApprovalTimeoutExpire, ApprovalTimeoutApprove)
+	}
+	return nil
+}
+
+// Timeout returns how long a request waits for a decision, 15 minutes by default
+func (p *ApprovalPolicy) Timeout() time.Duration {
+	if p.TimeoutSeconds == 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
+
+// AddedHourlyCost returns the hourly cost of delta more servers
+func (p *ApprovalPolicy) AddedHourlyCost(delta int) float64 {
+	return float64(delta) * p.ServerHourlyCost
+}
+
+// Requires reports whether the decision must wait for approval. Emergency and
+// operator-initiated scale-ups never wait.
+func (p *ApprovalPolicy) Requires(decision *ScalingDecision) bool {
+	if decision.Action != ActionScaleUp || decision.IsEmergency || decision.InitiatedBy != nil {
+		return false
+	}
+	delta := decision.ServerDelta()
+	if p.MaxDelta > 0 && delta > p.MaxDelta {
+		return true
+	}
+	return p.MaxHourlyCost > 0 && p.AddedHourlyCost(delta) > p.MaxHourlyCost
+}
+
+type ScalingRequestStatus string
+
+const (
+	ScalingRequestPending  ScalingRequestStatus = "pending"
+	ScalingRequestApproved ScalingRequestStatus = "approved"
+	ScalingRequestRejected ScalingRequestStatus = "rejected"
+	ScalingRequestExpired  ScalingRequestStatus = "expired"
+)
+
+// ScalingRequest is a scale-up waiting for approval. ResolvedBy stays nil when the
+// timeout policy resolved it.
+type ScalingRequest struct {
+	ID              string               `json:"id"`
+	ClusterID       string               `json:"cluster_id"`
+	Action          ScalingAction        `json:"action"`
+	CurrentServers  int                  `json:"current_servers"`
+	TargetServers   int                  `json:"target_servers"`
+	Reason          string               `json:"reason"`
+	AddedHourlyCost float64              `json:"added_hourly_cost,omitempty"`
+	Status          ScalingRequestStatus `json:"status"`
+	CreatedAt       time.Time            `json:"created_at"`
+	ExpiresAt       time.Time            `json:"expires_at"`
+	ResolvedAt      *time.Time           `json:"resolved_at,omitempty"`
+	ResolvedBy      *int                 `json:"resolved_by,omitempty"`
+	Outcome         DecisionOutcome      `json:"outcome,omitempty"`
+	Error           string               `json:"error,omitempty"`
+}
+
+func NewScalingRequest(decision *ScalingDecision, policy *ApprovalPolicy) *ScalingRequest {
+	now := time.Now()
+	return &ScalingRequest{
+		ID:              NewUUID(),
+		ClusterID:       decision.ClusterID,
+		Action:          decision.Action,
+		CurrentServers:  decision.CurrentServers,
+		TargetServers:   decision.TargetServers,
+		Reason:          decision.Reason,
+		AddedHourlyCost: policy.AddedHourlyCost(decision.ServerDelta()),
+		Status:          ScalingRequestPending,
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(policy.Timeout()),
+	}
+}
+
+// Resolve closes the request with status, on behalf of userID when one is given
+func (r *ScalingRequest) Resolve(status ScalingRequestStatus, userID *int) {
+	now := time.Now()
+	r.Status = status
+	r.ResolvedAt = &now
+	r.ResolvedBy = userID
+}
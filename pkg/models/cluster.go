@@ -2,6 +2,10 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -13,9 +17,196 @@ const (
 	ClusterStatusError  ClusterStatus = "error"
 )
 
+type ScalingMode string
+
+const (
+	// ScalingModeThreshold reacts to threshold breaches, trends and spikes
+	ScalingModeThreshold ScalingMode = "threshold"
+
+	// ScalingModeTargetTracking sizes the cluster so each signal sits at its target
+	ScalingModeTargetTracking ScalingMode = "target_tracking"
+)
+
+type ScalingSignal string
+
+const (
+	SignalCPU    ScalingSignal = "cpu"
+	SignalMemory ScalingSignal = "memory"
+	SignalLoad   ScalingSignal = "load"
+)
+
+type ClusterMode string
+
+const (
+	// ClusterModeManaged lets the autoscaler act on its decisions
+	ClusterModeManaged ClusterMode = "managed"
+
+	// ClusterModeObserve decides as usual but never scales, recording what it would have done
+	ClusterModeObserve ClusterMode = "observe"
+)
+
 type ClusterConfig struct {
-	CollectorEndpoint string  `json:"collector_endpoint,omitempty"`
-	TargetCPU         float64 `json:"target_cpu,omitempty"`
+	Mode                ClusterMode     `json:"mode,omitempty"`
+	CollectorEndpoint   string          `json:"collector_endpoint,omitempty"`
+	TargetCPU           float64         `json:"target_cpu,omitempty"`
+	TargetMemory        float64         `json:"target_memory,omitempty"`
+	ScalingMode         ScalingMode     `json:"scaling_mode,omitempty"`
+	ScalingSignals      []ScalingSignal `json:"scaling_signals,omitempty"`
+	TargetLoadPerServer float64         `json:"target_load_per_server,omitempty"`
+	CustomSignals       []CustomSignal  `json:"custom_signals,omitempty"`
+	Policy              *ScalingPolicy  `json:"policy,omitempty"`
+	Approval            *ApprovalPolicy `json:"approval,omitempty"`
+
+	// Overrides for the global analyzer and decision settings; nil keeps the global value
+	CPUHighThreshold         *float64 `json:"cpu_high_threshold,omitempty"`
+	CPULowThreshold          *float64 `json:"cpu_low_threshold,omitempty"`
+	MemoryHighThreshold      *float64 `json:"memory_high_threshold,omitempty"`
+	MemoryLowThreshold       *float64 `json:"memory_low_threshold,omitempty"`
+	EmergencyCPUThreshold    *float64 `json:"emergency_cpu_threshold,omitempty"`
+	SpikeThreshold           *float64 `json:"spike_threshold,omitempty"`
+	TrendWindowSeconds       *int     `json:"trend_window_seconds,omitempty"`
+	CPUStatistic             *string  `json:"cpu_statistic,omitempty"`
+	PercentileWindowSeconds  *int     `json:"percentile_window_seconds,omitempty"`
+	CooldownSeconds          *int     `json:"cooldown_seconds,omitempty"`
+	ScaleDownCooldownSeconds *int     `json:"scale_down_cooldown_seconds,omitempty"`
+	SustainedHighSeconds     *int     `json:"sustained_high_seconds,omitempty"`
+	SustainedLowSeconds      *int     `json:"sustained_low_seconds,omitempty"`
+	MaxScaleStep             *int     `json:"max_scale_step,omitempty"`
+	ScaleDownMode            *string  `json:"scale_down_mode,omitempty"`
+	MaxScaleDownStep         *int     `json:"max_scale_down_step,omitempty"`
+	MaxScaleDownPercent      *float64 `json:"max_scale_down_percent,omitempty"`
+	ScaleDownSafetyMargin    *float64 `json:"scale_down_safety_margin,omitempty"`
+	PredictionMinConfidence  *float64 `json:"prediction_min_confidence,omitempty"`
+	CollectIntervalSeconds   *int     `json:"collect_interval_seconds,omitempty"`
+}
+
+// Validate checks that the scaling settings are consistent
+func (c *ClusterConfig) Validate() error {
+	if c.TargetCPU < 0 || c.TargetCPU > 100 {
+		return errors.New("target_cpu must be between 0 and 100")
+	}
+	if c.TargetMemory < 0 || c.TargetMemory > 100 {
+		return errors.New("target_memory must be between 0 and 100")
+	}
+	if c.TargetLoadPerServer < 0 {
+		return errors.New("target_load_per_server must not be negative")
+	}
+	if c.CollectorEndpoint != "" {
+		u, err := url.Parse(c.CollectorEndpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("collector_endpoint must be an http or https URL")
+		}
+	}
+	if err := c.validateOverrides(); err != nil {
+		return err
+	}
+
+	switch c.Mode {
+	case "", ClusterModeManaged, ClusterModeObserve:
+	default:
+		return fmt.Errorf("mode must be one of: %s, %s", ClusterModeManaged, ClusterModeObserve)
+	}
+
+	switch c.ScalingMode {
+	case "", ScalingModeThreshold, ScalingModeTargetTracking:
+	default:
+		return fmt.Errorf("scaling_mode must be one of: %s, %s", ScalingModeThreshold, ScalingModeTargetTracking)
+	}
+
+	for _, signal := range c.ScalingSignals {
+		switch signal {
+		case SignalCPU, SignalMemory:
+		case SignalLoad:
+			if c.TargetLoadPerServer <= 0 {
+				return errors.New("target_load_per_server is required when scaling on load")
+			}
+		default:
+			return fmt.Errorf("scaling_signals must only contain: %s, %s, %s", SignalCPU, SignalMemory, SignalLoad)
+		}
+	}
+	if err := validateCustomSignals(c.CustomSignals); err != nil {
+		return err
+	}
+
+	if c.Policy != nil {
+		if err := c.Policy.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Approval != nil {
+		if err := c.Approval.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateOverrides checks the optional analyzer and decision overrides
+func (c *ClusterConfig) validateOverrides() error {
+	percentages := []struct {
+		name  string
+		value *float64
+	}{
+		{"cpu_high_threshold", c.CPUHighThreshold},
+		{"cpu_low_threshold", c.CPULowThreshold},
+		{"memory_high_threshold", c.MemoryHighThreshold},
+		{"memory_low_threshold", c.MemoryLowThreshold},
+		{"emergency_cpu_threshold", c.EmergencyCPUThreshold},
+		{"max_scale_down_percent", c.MaxScaleDownPercent},
+	}
+	for _, p := range percentages {
+		if p.value != nil && (*p.value <= 0 || *p.value > 100) {
+			return fmt.Errorf("%s must be between 0 and 100", p.name)
+		}
+	}
+	if c.CPUHighThreshold != nil && c.CPULowThreshold != nil && *c.CPUHighThreshold <= *c.CPULowThreshold {
+		return errors.New("cpu_high_threshold must be greater than cpu_low_threshold")
+	}
+	if c.MemoryHighThreshold != nil && c.MemoryLowThreshold != nil && *c.MemoryHighThreshold <= *c.MemoryLowThreshold {
+		return errors.New("memory_high_threshold must be greater than memory_low_threshold")
+	}
+
+	positives := []struct {
+		name  string
+		value *int
+	}{
+		{"trend_window_seconds", c.TrendWindowSeconds},
+		{"percentile_window_seconds", c.PercentileWindowSeconds},
+		{"cooldown_seconds", c.CooldownSeconds},
+		{"scale_down_cooldown_seconds", c.ScaleDownCooldownSeconds},
+		{"sustained_high_seconds", c.SustainedHighSeconds},
+		{"sustained_low_seconds", c.SustainedLowSeconds},
+		{"max_scale_step", c.MaxScaleStep},
+		{"max_scale_down_step", c.MaxScaleDownStep},
+	}
+	for _, p := range positives {
+		if p.value != nil && *p.value <= 0 {
+			return fmt.Errorf("%s must be positive", p.name)
+		}
+	}
+
+	// The pipeline gives each cycle its interval minus a second to finish
+	if c.CollectIntervalSeconds != nil && *c.CollectIntervalSeconds < 2 {
+		return errors.New("collect_interval_seconds must be at least 2")
+	}
+	if c.SpikeThreshold != nil && *c.SpikeThreshold <= 0 {
+		return errors.New("spike_threshold must be positive")
+	}
+	if c.ScaleDownSafetyMargin != nil && *c.ScaleDownSafetyMargin < 0 {
+		return errors.New("scale_down_safety_margin must not be negative")
+	}
+	if c.PredictionMinConfidence != nil && (*c.PredictionMinConfidence <= 0 || *c.PredictionMinConfidence > 1) {
+		return errors.New("prediction_min_confidence must be between 0 and 1")
+	}
+	if c.CPUStatistic != nil && !CPUStatistic(*c.CPUStatistic).Valid() {
+		return errors.New("cpu_statistic must be one of: avg, max, p50, p90, p95, p99")
+	}
+	if c.ScaleDownMode != nil && *c.ScaleDownMode != "conservative" && *c.ScaleDownMode != "proportional" {
+		return errors.New("scale_down_mode must be one of: conservative, proportional")
+	}
+
+	return nil
 }
 
 type Cluster struct {
@@ -49,6 +240,20 @@ func (c *Cluster) IsActive() bool {
 	return c.Status == ClusterStatusActive
 }
 
+// IsObserveOnly reports whether the autoscaler must leave the cluster's size alone
+func (c *Cluster) IsObserveOnly() bool {
+	return c.Config != nil && c.Config.Mode == ClusterModeObserve
+}
+
+// MetricsEndpoint returns the URL the collector polls: the configured
+// CollectorEndpoint, or the cluster's path under base
+func (c *Cluster) MetricsEndpoint(base string) string {
+	if c.Config != nil && c.Config.CollectorEndpoint != "" {
+		return c.Config.CollectorEndpoint
+	}
+	return strings.TrimSuffix(base, "/") + "/" + c.ID
+}
+
 func (c *Cluster) ConfigJSON() ([]byte, error) {
 	if c.Config == nil {
 		return []byte("{}"), nil
@@ -21,6 +21,12 @@ func (cs *ClusterState) CanScaleDown(minServers int) bool {
 	return cs.ActiveServers > minServers
 }
 
+// CommittedServers counts the servers serving or on their way. Draining servers
+// are already leaving and don't count.
+func (cs *ClusterState) CommittedServers() int {
+	return cs.ActiveServers + cs.ProvisioningCnt
+}
+
 func (cs *ClusterState) AvailableCapacity(maxServers int) int {
 	return maxServers - cs.TotalServers
 }
\ No newline at end of file
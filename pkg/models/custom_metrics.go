@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// customMetricName keeps names usable in reasons, trace checks and query parameters
+var customMetricName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,99}$`)
+
+// CustomAggregation says which cluster-wide figure of a custom metric a signal acts on
+type CustomAggregation string
+
+const (
+	CustomAggregationAvg CustomAggregation = "avg"
+	CustomAggregationMax CustomAggregation = "max"
+	CustomAggregationSum CustomAggregation = "sum"
+)
+
+// CustomMetricValue summarises one custom metric across the servers that reported
+// it. A metric reported for the whole cluster counts as a single reading.
+type CustomMetricValue struct {
+	Avg   float64 `json:"avg"`
+	Max   float64 `json:"max"`
+	Sum   float64 `json:"sum"`
+	Count int     `json:"count"`
+}
+
+// Of picks the aggregation's figure; anything unrecognised falls back to the average
+func (v CustomMetricValue) Of(aggregation CustomAggregation) float64 {
+	switch aggregation {
+	case CustomAggregationMax:
+		return v.Max
+	case CustomAggregationSum:
+		return v.Sum
+	default:
+		return v.Avg
+	}
+}
+
+// CustomSignal scales a cluster on a metric its services export, such as queue
+// depth or p99 latency.
+//
+// Target tracking sizes the cluster so the metric sits at Target. A sum is a total
+// shared by the servers, so Target is per server; an average or maximum is a
+// per-server figure that falls as servers are added, like CPU.
+//
+// Threshold scaling adds servers while the metric is at or above High, and holds
+// off scaling down until it is at or below Low.
+type CustomSignal struct {
+	Name        string            `json:"name"`
+	Aggregation CustomAggregation `json:"aggregation,omitempty"`
+	Target      float64           `json:"target,omitempty"`
+	High        *float64          `json:"high,omitempty"`
+	Low         *float64          `json:"low,omitempty"`
+}
+
+// Signal names the custom signal in decision reasons and traces
+func (s CustomSignal) Signal() ScalingSignal {
+	return ScalingSignal("custom_" + s.Name)
+}
+
+func (s CustomSignal) Validate() error {
+	if !customMetricName.MatchString(s.Name) {
+		return fmt.Errorf("custom signal name %q must be lowercase letters, digits and underscores, starting with a letter", s.Name)
+	}
+	switch s.Aggregation {
+	case "", CustomAggregationAvg, CustomAggregationMax, CustomAggregationSum:
+	default:
+		return fmt.Errorf("custom signal %s: aggregation must be one of: %s, %s, %s",
+			s.Name, CustomAggregationAvg, CustomAggregationMax, CustomAggregationSum)
+	}
+	if s.Target < 0 {
+		return fmt.Errorf("custom signal %s: target must not be negative", s.Name)
+	}
+	if s.Target == 0 && s.High == nil && s.Low == nil {
+		return fmt.Errorf("custom signal %s: needs a target, a high threshold or a low threshold", s.Name)
+	}
+	if s.High != nil && s.Low != nil && *s.High <= *s.Low {
+		return fmt.Errorf("custom signal %s: high must be greater than low", s.Name)
+	}
+	return nil
+}
+
+// ValidCustomMetricName reports whether name can be used for a custom metric
+func ValidCustomMetricName(name string) bool {
+	return customMetricName.MatchString(name)
+}
+
+func validateCustomSignals(signals []CustomSignal) error {
+	seen := make(map[string]bool, len(signals))
+	for _, s := range signals {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("custom_signals lists %s more than once", s.Name)
+		}
+		seen[s.Name] = true
+	}
+	return nil
+}
+
+// aggregateCustom summarises each custom metric across servers. Metrics reported
+// for the whole cluster replace any per-server readings of the same name.
+func aggregateCustom(servers []ServerMetric, cluster map[string]float64) map[string]CustomMetricValue {
+	var custom map[string]CustomMetricValue
+	for _, s := range servers {
+		for name, value := range s.Custom {
+			if custom == nil {
+				custom = make(map[string]CustomMetricValue)
+			}
+			v, seen := custom[name]
+			if !seen || value > v.Max {
+				v.Max = value
+			}
+			v.Sum += value
+			v.Count++
+			custom[name] = v
+		}
+	}
+	for name, v := range custom {
+		v.Avg = v.Sum / float64(v.Count)
+		custom[name] = v
+	}
+
+	for name, value := range cluster {
+		if custom == nil {
+			custom = make(map[string]CustomMetricValue)
+		}
+		custom[name] = CustomMetricValue{Avg: value, Max: value, Sum: value, Count: 1}
+	}
+	return custom
+}
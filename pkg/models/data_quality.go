@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+type DataQualityIssueType string
+
+const (
+	DataQualityMissingTimestamp DataQualityIssueType = "missing_timestamp"
+	DataQualityStaleTimestamp   DataQualityIssueType = "stale_timestamp"
+	DataQualityNoServers        DataQualityIssueType = "no_servers"
+	DataQualityServerCount      DataQualityIssueType = "server_count_mismatch"
+	DataQualityOutOfRange       DataQualityIssueType = "out_of_range"
+)
+
+// DataQualityIssue is one problem found in a cycle's collected metrics
+type DataQualityIssue struct {
+	Type     DataQualityIssueType `json:"type"`
+	ServerID string               `json:"server_id,omitempty"`
+	Message  string               `json:"message"`
+}
+
+// DataQualityPolicy is what the pipeline does with a cycle whose metrics have issues
+type DataQualityPolicy string
+
+const (
+	// DataQualitySkip drops the cycle without analysing or deciding
+	DataQualitySkip DataQualityPolicy = "skip"
+	// DataQualityHoldLast decides on the last analysis whose metrics had no issues
+	DataQualityHoldLast DataQualityPolicy = "hold_last"
+	// DataQualityBlockScaleDown decides on the metrics as collected but never scales down
+	DataQualityBlockScaleDown DataQualityPolicy = "block_scale_down"
+)
+
+func (p DataQualityPolicy) Valid() bool {
+	switch p {
+	case DataQualitySkip, DataQualityHoldLast, DataQualityBlockScaleDown:
+		return true
+	}
+	return false
+}
+
+// DataQualityReport records the issues found in one cycle's metrics and how the
+// pipeline handled them. Applied differs from Policy when hold_last had no usable
+// analysis to fall back on and the cycle was skipped instead.
+type DataQualityReport struct {
+	ClusterID        string             `json:"cluster_id"`
+	Timestamp        time.Time          `json:"timestamp"`
+	MetricsTimestamp time.Time          `json:"metrics_timestamp"`
+	ServerCount      int                `json:"server_count"`
+	ExpectedServers  *int               `json:"expected_servers,omitempty"`
+	Issues           []DataQualityIssue `json:"issues"`
+	Policy           DataQualityPolicy  `json:"policy"`
+	Applied          DataQualityPolicy  `json:"applied"`
+}
@@ -10,18 +10,29 @@ const (
 	ActionMaintain  ScalingAction = "MAINTAIN"
 )
 
-// ScalingDecision represents a scaling decision made by the decision engine
+// ScalingDecision represents a scaling decision made by the decision engine, or
+// requested by an operator.
+// CurrentServers includes servers still provisioning, so the delta to
+// TargetServers is only the capacity that isn't already on its way.
 type ScalingDecision struct {
-	ClusterID      string        `json:"cluster_id"`
-	Timestamp      time.Time     `json:"timestamp"`
-	Action         ScalingAction `json:"action"`
-	CurrentServers int           `json:"current_servers"`
-	TargetServers  int           `json:"target_servers"`
-	Reason         string        `json:"reason"`
-	PredictionUsed bool          `json:"prediction_used"`
-	Confidence     float64       `json:"confidence,omitempty"`
-	IsEmergency    bool          `json:"is_emergency"`
-	CooldownActive bool          `json:"cooldown_active"`
+	ClusterID           string         `json:"cluster_id"`
+	Timestamp           time.Time      `json:"timestamp"`
+	Action              ScalingAction  `json:"action"`
+	CurrentServers      int            `json:"current_servers"`
+	TargetServers       int            `json:"target_servers"`
+	ProvisioningServers int            `json:"provisioning_servers"`
+	DrainingServers     int            `json:"draining_servers"`
+	Reason              string         `json:"reason"`
+	Rule                string         `json:"rule,omitempty"`
+	PredictionUsed      bool           `json:"prediction_used"`
+	Confidence          float64        `json:"confidence,omitempty"`
+	IsEmergency         bool           `json:"is_emergency"`
+	CooldownActive      bool           `json:"cooldown_active"`
+	Prediction          *Prediction    `json:"prediction,omitempty"`
+	Trace               *DecisionTrace `json:"trace,omitempty"`
+	InitiatedBy         *int           `json:"initiated_by,omitempty"`       // user behind a manual scale or pin
+	Freeze              string         `json:"freeze,omitempty"`             // freeze window that suppressed the action
+	ScalingRequestID    string         `json:"scaling_request_id,omitempty"` // approval request the action waits on or executes
 }
 
 func (d *ScalingDecision) ServerDelta() int {
@@ -30,4 +41,144 @@ func (d *ScalingDecision) ServerDelta() int {
 
 func (d *ScalingDecision) ShouldExecute() bool {
 	return d.Action != ActionMaintain && !d.CooldownActive
-}
\ No newline at end of file
+}
+
+// DecisionOutcome records what the pipeline did with a decision
+type DecisionOutcome string
+
+const (
+	OutcomeMaintained DecisionOutcome = "maintained"
+	OutcomeSuppressed DecisionOutcome = "cooldown_suppressed"
+	OutcomeExecuted   DecisionOutcome = "executed"
+	OutcomePartial    DecisionOutcome = "partial"
+	OutcomeFailed     DecisionOutcome = "failed"
+
+	// OutcomeObserved marks a scaling action an observe-mode cluster would have taken
+	OutcomeObserved DecisionOutcome = "observed"
+
+	// OutcomePendingApproval marks a scale-up held back until someone approves it
+	OutcomePendingApproval DecisionOutcome = "pending_approval"
+
+	// OutcomeFrozen marks a scaling action an open freeze window blocked
+	OutcomeFrozen DecisionOutcome = "freeze_suppressed"
+
+	// OutcomeDataQualityBlocked marks a scale-down blocked because its metrics
+	// failed their data-quality checks
+	OutcomeDataQualityBlocked DecisionOutcome = "data_quality_blocked"
+)
+
+// DecisionRecord is a decision together with what became of it
+type DecisionRecord struct {
+	ScalingDecision
+	Outcome DecisionOutcome `json:"outcome"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func NewDecisionRecord(decision ScalingDecision, outcome DecisionOutcome, err error) *DecisionRecord {
+	record := &DecisionRecord{
+		ScalingDecision: decision,
+		Outcome:         outcome,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	return record
+}
+
+// DecisionTrace records what the engine looked at while deciding, so that a
+// MAINTAIN can be explained without reading logs
+type DecisionTrace struct {
+	Inputs   DecisionInputs  `json:"inputs"`
+	Limits   DecisionLimits  `json:"limits"`
+	Cooldown CooldownState   `json:"cooldown"`
+	Checks   []DecisionCheck `json:"checks"`
+	Clamps   []DecisionClamp `json:"clamps,omitempty"`
+	Damping  *DampingState   `json:"damping,omitempty"`
+}
+
+type DecisionInputs struct {
+	AvgCPU               float64         `json:"avg_cpu"`
+	CPUStatistic         CPUStatistic    `json:"cpu_statistic,omitempty"`
+	SignalCPU            float64         `json:"signal_cpu"`
+	AvgMemory            float64         `json:"avg_memory"`
+	AvgLoad              float64         `json:"avg_load"`
+	TotalLoad            float64         `json:"total_load"`
+	CPUStatus            ThresholdStatus `json:"cpu_status"`
+	MemoryStatus         ThresholdStatus `json:"memory_status"`
+	Trend                Trend           `json:"trend"`
+	HasSpike             bool            `json:"has_spike"`
+	IsAnomaly            bool            `json:"is_anomaly"`
+	AnomalyScore         float64         `json:"anomaly_score,omitempty"`
+	ActiveServers        int             `json:"active_servers"`
+	TotalServers         int             `json:"total_servers"`
+	ProvisioningServers  int             `json:"provisioning_servers"`
+	DrainingServers      int             `json:"draining_servers"`
+	PredictedCPU         *float64        `json:"predicted_cpu,omitempty"`
+	PredictionConfidence *float64        `json:"prediction_confidence,omitempty"`
+
+	// Custom holds the figure each custom signal acted on, by metric name
+	Custom map[string]float64 `json:"custom,omitempty"`
+}
+
+type DecisionLimits struct {
+	MinServers       int         `json:"min_servers"`
+	MaxServers       int         `json:"max_servers"`
+	MaxScaleStep     int         `json:"max_scale_step"`
+	MaxScaleDownStep int         `json:"max_scale_down_step"`
+	ScalingMode      ScalingMode `json:"scaling_mode"`
+	Override         string      `json:"override,omitempty"`
+}
+
+type CooldownState struct {
+	ScaleUpActive             bool    `json:"scale_up_active"`
+	ScaleUpRemainingSeconds   float64 `json:"scale_up_remaining_seconds,omitempty"`
+	ScaleDownActive           bool    `json:"scale_down_active"`
+	ScaleDownRemainingSeconds float64 `json:"scale_down_remaining_seconds,omitempty"`
+}
+
+// DampingState reports whether flap damping is holding back a cluster that kept
+// reversing its scaling direction
+type DampingState struct {
+	Active                   bool       `json:"active"`
+	Reversals                int        `json:"reversals"`
+	Since                    *time.Time `json:"since,omitempty"`
+	RelaxesAt                *time.Time `json:"relaxes_at,omitempty"`
+	ScaleDownCooldownSeconds float64    `json:"scale_down_cooldown_seconds,omitempty"`
+	CPULowThreshold          float64    `json:"cpu_low_threshold,omitempty"`
+}
+
+// DecisionCheck is one condition the engine evaluated, in evaluation order
+type DecisionCheck struct {
+	Name      string      `json:"name"`
+	Matched   bool        `json:"matched"`
+	Value     interface{} `json:"value,omitempty"`
+	Threshold interface{} `json:"threshold,omitempty"`
+}
+
+// DecisionClamp records a server count or step size that a limit cut down
+type DecisionClamp struct {
+	Limit string `json:"limit"`
+	From  int    `json:"from"`
+	To    int    `json:"to"`
+}
+
+// Check records a condition and returns whether it matched, so it can wrap an if-condition.
+// It is a no-op on a nil trace.
+func (t *DecisionTrace) Check(name string, matched bool, value, threshold interface{}) bool {
+	if t != nil {
+		t.Checks = append(t.Checks, DecisionCheck{
+			Name:      name,
+			Matched:   matched,
+			Value:     value,
+			Threshold: threshold,
+		})
+	}
+	return matched
+}
+
+// Clamp records a limit being applied when it changed the server count
+func (t *DecisionTrace) Clamp(limit string, from, to int) {
+	if t != nil && from != to {
+		t.Clamps = append(t.Clamps, DecisionClamp{Limit: limit, From: from, To: to})
+	}
+}
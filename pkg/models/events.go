@@ -5,17 +5,34 @@ import "time"
 type EventType string
 
 const (
-	EventTypeMetricCollected EventType = "metric_collected"
-	EventTypeMetricAnalyzed  EventType = "metric_analyzed"
-	EventTypeDecisionMade    EventType = "decision_made"
-	EventTypeScalingStarted  EventType = "scaling_started"
-	EventTypeScalingComplete EventType = "scaling_complete"
-	EventTypeScalingFailed   EventType = "scaling_failed"
-	EventTypeServerAdded     EventType = "server_added"
-	EventTypeServerRemoved   EventType = "server_removed"
-	EventTypeServerActivated EventType = "server_activated"
-	EventTypeAlert           EventType = "alert"
-	EventTypeError           EventType = "error"
+	EventTypeMetricCollected  EventType = "metric_collected"
+	EventTypeMetricAnalyzed   EventType = "metric_analyzed"
+	EventTypePredictionMade   EventType = "prediction_made"
+	EventTypeDecisionMade     EventType = "decision_made"
+	EventTypeDecisionRecorded EventType = "decision_recorded"
+	EventTypeScalingStarted   EventType = "scaling_started"
+	EventTypeScalingComplete  EventType = "scaling_complete"
+	EventTypeScalingFailed    EventType = "scaling_failed"
+	EventTypeWouldScale       EventType = "would_scale"
+	EventTypeServerAdded      EventType = "server_added"
+	EventTypeServerRemoved    EventType = "server_removed"
+	EventTypeServerActivated  EventType = "server_activated"
+	EventTypeScheduleStarted  EventType = "schedule_started"
+	EventTypeScheduleEnded    EventType = "schedule_ended"
+	EventTypeAlert            EventType = "alert"
+	EventTypeError            EventType = "error"
+
+	// Scale-ups waiting for approval
+	EventTypeScalingRequestCreated  EventType = "scaling_request_created"
+	EventTypeScalingRequestResolved EventType = "scaling_request_resolved"
+
+	// Per-server problems cluster averages hide
+	EventTypeServerHot     EventType = "server_hot"
+	EventTypeServerStale   EventType = "server_stale"
+	EventTypeLoadImbalance EventType = "load_imbalance"
+
+	// Collected metrics that failed validation
+	EventTypeDataQuality EventType = "data_quality"
 )
 
 type EventSeverity string
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Longest a freeze window may stay open, one-off or per firing
+const maxFreezeDuration = 7 * 24 * time.Hour
+
+type FreezeScope string
+
+const (
+	// FreezeScaleDown blocks scale-down only
+	FreezeScaleDown FreezeScope = "scale_down"
+
+	// FreezeAll blocks every scaling action
+	FreezeAll FreezeScope = "all"
+)
+
+// FreezeWindow blocks scaling for one cluster, or for every cluster of its user when
+// ClusterID is nil. A one-off window runs from StartsAt to EndsAt; a recurring one
+// opens each time Cron fires in Timezone and lasts DurationMinutes.
+type FreezeWindow struct {
+	ID              string      `json:"id"`
+	UserID          int         `json:"user_id"`
+	ClusterID       *string     `json:"cluster_id,omitempty"`
+	Name            string      `json:"name"`
+	Scope           FreezeScope `json:"scope"`
+	AllowEmergency  bool        `json:"allow_emergency"`
+	StartsAt        *time.Time  `json:"starts_at,omitempty"`
+	EndsAt          *time.Time  `json:"ends_at,omitempty"`
+	Cron            string      `json:"cron,omitempty"`
+	Timezone        string      `json:"timezone,omitempty"`
+	DurationMinutes int         `json:"duration_minutes,omitempty"`
+	Reason          string      `json:"reason,omitempty"`
+	Enabled         bool        `json:"enabled"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
+
+func NewFreezeWindow(userID int, name string) *FreezeWindow {
+	now := time.Now()
+	return &FreezeWindow{
+		ID:        NewUUID(),
+		UserID:    userID,
+		Name:      name,
+		Scope:     FreezeAll,
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (f *FreezeWindow) IsRecurring() bool {
+	return f.Cron != ""
+}
+
+// Duration returns how long each firing of a recurring window keeps it open
+func (f *FreezeWindow) Duration() time.Duration {
+	return time.Duration(f.DurationMinutes) * time.Minute
+}
+
+// Location resolves the recurring window's time zone, defaulting to UTC
+func (f *FreezeWindow) Location() (*time.Location, error) {
+	if f.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(f.Timezone)
+}
+
+// Blocks reports whether the freeze stops a scaling action. An emergency
+// scale-up passes when the freeze allows emergencies.
+func (f *FreezeWindow) Blocks(action ScalingAction, isEmergency bool) bool {
+	switch action {
+	case ActionScaleDown:
+		return true
+	case ActionScaleUp:
+		return f.Scope == FreezeAll && !(isEmergency && f.AllowEmergency)
+	}
+	return false
+}
+
+// Validate checks everything except the cron expression, which is parsed by the scheduler
+func (f *FreezeWindow) Validate() error {
+	if f.Name == "" {
+		return errors.New("name is required")
+	}
+	if f.Scope != FreezeScaleDown && f.Scope != FreezeAll {
+		return fmt.Errorf("scope must be %q or %q", FreezeScaleDown, FreezeAll)
+	}
+
+	oneOff := f.StartsAt != nil || f.EndsAt != nil
+	if oneOff == f.IsRecurring() {
+		return errors.New("either starts_at/ends_at or cron is required, not both")
+	}
+
+	if oneOff {
+		if f.StartsAt == nil || f.EndsAt == nil {
+			return errors.New("starts_at and ends_at are both required")
+		}
+		if !f.EndsAt.After(*f.StartsAt) {
+			return errors.New("ends_at must be after starts_at")
+		}
+		if f.EndsAt.Sub(*f.StartsAt) > maxFreezeDuration {
+			return fmt.Errorf("a freeze may last at most %s", maxFreezeDuration)
+		}
+		return nil
+	}
+
+	if _, err := f.Location(); err != nil {
+		return fmt.Errorf("unknown timezone %q", f.Timezone)
+	}
+	if f.Duration() < time.Minute || f.Duration() > maxFreezeDuration {
+		return fmt.Errorf("duration_minutes must be between 1 and %d", int(maxFreezeDuration.Minutes()))
+	}
+	return nil
+}
+
+// ActiveFreeze is a freeze window that is open, with the time it closes
+type ActiveFreeze struct {
+	FreezeWindow
+	ActiveUntil time.Time `json:"active_until"`
+}
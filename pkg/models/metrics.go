@@ -8,6 +8,9 @@ type ServerMetric struct {
 	CPUUsage    float64 `json:"cpu_usage"`
 	MemoryUsage float64 `json:"memory_usage"`
 	RequestLoad int     `json:"request_load"`
+
+	// Custom holds service-exported metrics by name, e.g. queue depth or p99 latency
+	Custom map[string]float64 `json:"custom,omitempty"`
 }
 
 // ClusterMetrics represents collected metrics for a cluster
@@ -15,6 +18,13 @@ type ClusterMetrics struct {
 	ClusterID string         `json:"cluster_id"`
 	Timestamp time.Time      `json:"timestamp"`
 	Servers   []ServerMetric `json:"servers"`
+
+	// Custom holds metrics reported for the whole cluster rather than per server
+	Custom map[string]float64 `json:"custom,omitempty"`
+
+	// EstimatedTimestamp is set when the source gave no usable timestamp and
+	// Timestamp is the collection time instead
+	EstimatedTimestamp bool `json:"estimated_timestamp,omitempty"`
 }
 
 // AggregatedMetrics represents computed metrics for a cluster
@@ -28,6 +38,9 @@ type AggregatedMetrics struct {
 	MinCPU        float64   `json:"min_cpu"`
 	ServerCount   int       `json:"server_count"`
 	ActiveServers int       `json:"active_servers"`
+	CPUPercentiles
+
+	Custom map[string]CustomMetricValue `json:"custom,omitempty"`
 }
 
 // MetricRecord represents a single metric entry for database storage
@@ -46,10 +59,12 @@ func (cm *ClusterMetrics) CalculateAggregates() AggregatedMetrics {
 		return AggregatedMetrics{
 			ClusterID:  cm.ClusterID,
 			Timestamp: cm.Timestamp,
+			Custom:    aggregateCustom(nil, cm.Custom),
 		}
 	}
 
 	var totalCPU, totalMemory, totalLoad float64
+	cpus := make([]float64, 0, len(cm.Servers))
 	maxCPU := cm.Servers[0].CPUUsage
 	minCPU := cm.Servers[0].CPUUsage
 
@@ -57,6 +72,7 @@ func (cm *ClusterMetrics) CalculateAggregates() AggregatedMetrics {
 		totalCPU += s.CPUUsage
 		totalMemory += s.MemoryUsage
 		totalLoad += float64(s.RequestLoad)
+		cpus = append(cpus, s.CPUUsage)
 
 		if s.CPUUsage > maxCPU {
 			maxCPU = s.CPUUsage
@@ -77,5 +93,7 @@ func (cm *ClusterMetrics) CalculateAggregates() AggregatedMetrics {
 		MinCPU:        minCPU,
 		ServerCount:   count,
 		ActiveServers: count,
+		CPUPercentiles: NewCPUPercentiles(cpus),
+		Custom:        aggregateCustom(cm.Servers, cm.Custom),
 	}
 }
\ No newline at end of file
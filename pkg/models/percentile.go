@@ -0,0 +1,82 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// CPUStatistic names the CPU figure thresholds and target tracking act on
+type CPUStatistic string
+
+const (
+	CPUStatisticAvg CPUStatistic = "avg"
+	CPUStatisticMax CPUStatistic = "max"
+	CPUStatisticP50 CPUStatistic = "p50"
+	CPUStatisticP90 CPUStatistic = "p90"
+	CPUStatisticP95 CPUStatistic = "p95"
+	CPUStatisticP99 CPUStatistic = "p99"
+)
+
+func (s CPUStatistic) Valid() bool {
+	switch s {
+	case CPUStatisticAvg, CPUStatisticMax, CPUStatisticP50, CPUStatisticP90, CPUStatisticP95, CPUStatisticP99:
+		return true
+	}
+	return false
+}
+
+// Of picks the statistic's figure; anything unrecognised falls back to the average
+func (s CPUStatistic) Of(avg, max float64, percentiles CPUPercentiles) float64 {
+	switch s {
+	case CPUStatisticMax:
+		return max
+	case CPUStatisticP50:
+		return percentiles.P50
+	case CPUStatisticP90:
+		return percentiles.P90
+	case CPUStatisticP95:
+		return percentiles.P95
+	case CPUStatisticP99:
+		return percentiles.P99
+	default:
+		return avg
+	}
+}
+
+// CPUPercentiles summarises the spread of CPU readings
+type CPUPercentiles struct {
+	P50 float64 `json:"p50_cpu"`
+	P90 float64 `json:"p90_cpu"`
+	P95 float64 `json:"p95_cpu"`
+	P99 float64 `json:"p99_cpu"`
+}
+
+// NewCPUPercentiles computes the percentiles of values, which it leaves unsorted
+func NewCPUPercentiles(values []float64) CPUPercentiles {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	return CPUPercentiles{
+		P50: Percentile(sorted, 50),
+		P90: Percentile(sorted, 90),
+		P95: Percentile(sorted, 95),
+		P99: Percentile(sorted, 99),
+	}
+}
+
+// Percentile returns the p-th percentile of sorted values, interpolating between
+// the closest ranks the way PostgreSQL's percentile_cont does
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
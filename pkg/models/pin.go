@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ClusterPin holds a cluster at DesiredServers until Until. Automatic scaling
+// decisions are skipped while the pin is active.
+type ClusterPin struct {
+	DesiredServers int       `json:"desired_servers"`
+	Until          time.Time `json:"until"`
+	PinnedBy       int       `json:"pinned_by"`
+	PinnedAt       time.Time `json:"pinned_at"`
+}
+
+func (p *ClusterPin) IsActive(now time.Time) bool {
+	return now.Before(p.Until)
+}
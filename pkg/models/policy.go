@@ -0,0 +1,178 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+const maxPolicyRules = 50
+
+// Fields a policy condition can reference
+const (
+	PolicyFieldAvgCPU               = "avg_cpu"
+	PolicyFieldAvgMemory            = "avg_memory"
+	PolicyFieldAvgLoad              = "avg_load"
+	PolicyFieldTotalLoad            = "total_load"
+	PolicyFieldTrend                = "trend"
+	PolicyFieldHasSpike             = "has_spike"
+	PolicyFieldIsAnomaly            = "is_anomaly"
+	PolicyFieldSustainedHighSecs    = "sustained_high_seconds"
+	PolicyFieldSustainedLowSecs     = "sustained_low_seconds"
+	PolicyFieldSustainedMemHighSecs = "sustained_memory_high_seconds"
+	PolicyFieldSustainedMemLowSecs  = "sustained_memory_low_seconds"
+	PolicyFieldPredictedCPU         = "predicted_cpu"
+	PolicyFieldPredictionConfidence = "prediction_confidence"
+)
+
+type PolicyOperator string
+
+const (
+	OperatorGT  PolicyOperator = "gt"
+	OperatorGTE PolicyOperator = "gte"
+	OperatorLT  PolicyOperator = "lt"
+	OperatorLTE PolicyOperator = "lte"
+	OperatorEQ  PolicyOperator = "eq"
+	OperatorNEQ PolicyOperator = "neq"
+)
+
+type PolicyActionType string
+
+const (
+	PolicyActionAdd            PolicyActionType = "add"
+	PolicyActionAddPercent     PolicyActionType = "add_percent"
+	PolicyActionSetTarget      PolicyActionType = "set_target"
+	PolicyActionBlockScaleDown PolicyActionType = "block_scale_down"
+)
+
+// ScalingPolicy is an ordered list of rules evaluated before the built-in logic.
+// The first matching scaling rule decides; block_scale_down rules only veto scale-downs.
+type ScalingPolicy struct {
+	Rules []PolicyRule `json:"rules"`
+
+	// DisableDefaults skips the built-in rules when no policy rule matches
+	DisableDefaults bool `json:"disable_defaults,omitempty"`
+}
+
+// PolicyRule fires when all of its conditions match
+type PolicyRule struct {
+	Name       string            `json:"name"`
+	Conditions []PolicyCondition `json:"conditions"`
+	Action     PolicyAction      `json:"action"`
+}
+
+// PolicyCondition compares an analyzed field against a value.
+// Value is a number for numeric fields, a trend name for trend, and a bool for has_spike and is_anomaly.
+type PolicyCondition struct {
+	Field    string         `json:"field"`
+	Operator PolicyOperator `json:"operator"`
+	Value    interface{}    `json:"value"`
+}
+
+type PolicyAction struct {
+	Type  PolicyActionType `json:"type"`
+	Value float64          `json:"value,omitempty"`
+}
+
+var numericPolicyFields = map[string]bool{
+	PolicyFieldAvgCPU:               true,
+	PolicyFieldAvgMemory:            true,
+	PolicyFieldAvgLoad:              true,
+	PolicyFieldTotalLoad:            true,
+	PolicyFieldSustainedHighSecs:    true,
+	PolicyFieldSustainedLowSecs:     true,
+	PolicyFieldSustainedMemHighSecs: true,
+	PolicyFieldSustainedMemLowSecs:  true,
+	PolicyFieldPredictedCPU:         true,
+	PolicyFieldPredictionConfidence: true,
+}
+
+func (p *ScalingPolicy) Validate() error {
+	if len(p.Rules) == 0 {
+		return errors.New("policy must contain at least one rule")
+	}
+	if len(p.Rules) > maxPolicyRules {
+		return fmt.Errorf("policy must not contain more than %d rules", maxPolicyRules)
+	}
+
+	names := make(map[string]bool, len(p.Rules))
+	for i, rule := range p.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("policy rule %d: name is required", i)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("policy rule %q: duplicate name", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("policy rule %q: %w", rule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PolicyRule) validate() error {
+	if len(r.Conditions) == 0 {
+		return errors.New("at least one condition is required")
+	}
+	for _, condition := range r.Conditions {
+		if err := condition.validate(); err != nil {
+			return err
+		}
+	}
+
+	switch r.Action.Type {
+	case PolicyActionAdd:
+		if r.Action.Value < 1 || r.Action.Value != float64(int(r.Action.Value)) {
+			return errors.New("action add requires a whole number of servers")
+		}
+	case PolicyActionAddPercent:
+		if r.Action.Value <= 0 {
+			return fmt.Errorf("action %s requires a positive value", r.Action.Type)
+		}
+	case PolicyActionSetTarget:
+		if r.Action.Value < 1 || r.Action.Value != float64(int(r.Action.Value)) {
+			return errors.New("action set_target requires a whole number of servers")
+		}
+	case PolicyActionBlockScaleDown:
+	default:
+		return fmt.Errorf("unknown action %q", r.Action.Type)
+	}
+
+	return nil
+}
+
+func (c *PolicyCondition) validate() error {
+	switch c.Operator {
+	case OperatorGT, OperatorGTE, OperatorLT, OperatorLTE, OperatorEQ, OperatorNEQ:
+	default:
+		return fmt.Errorf("condition on %s: unknown operator %q", c.Field, c.Operator)
+	}
+
+	switch {
+	case numericPolicyFields[c.Field]:
+		if _, ok := c.Value.(float64); !ok {
+			return fmt.Errorf("condition on %s: value must be a number", c.Field)
+		}
+	case c.Field == PolicyFieldTrend:
+		trend, ok := c.Value.(string)
+		if !ok || (Trend(trend) != TrendRising && Trend(trend) != TrendFalling && Trend(trend) != TrendStable) {
+			return fmt.Errorf("condition on %s: value must be one of rising, falling, stable", c.Field)
+		}
+		if c.Operator != OperatorEQ && c.Operator != OperatorNEQ {
+			return fmt.Errorf("condition on %s: only eq and neq are supported", c.Field)
+		}
+	case c.Field == PolicyFieldHasSpike || c.Field == PolicyFieldIsAnomaly:
+		if _, ok := c.Value.(bool); !ok {
+			return fmt.Errorf("condition on %s: value must be true or false", c.Field)
+		}
+		if c.Operator != OperatorEQ && c.Operator != OperatorNEQ {
+			return fmt.Errorf("condition on %s: only eq and neq are supported", c.Field)
+		}
+	default:
+		return fmt.Errorf("unknown condition field %q", c.Field)
+	}
+
+	return nil
+}
@@ -9,9 +9,23 @@ type Prediction struct {
 	ClusterID    string    `json:"cluster_id"`
 	ForecastTime time.Time `json:"forecast_time"`
 	PredictedCPU float64   `json:"predicted_cpu"`
+	LowerBound   float64   `json:"lower_bound,omitempty"`
+	UpperBound   float64   `json:"upper_bound,omitempty"`
 	ActualCPU    *float64  `json:"actual_cpu,omitempty"`
 	Confidence   float64   `json:"confidence"`
 	ModelVersion string    `json:"model_version,omitempty"`
+
+	// Components holds the individual forecasts blended into an ensemble prediction
+	Components []PredictionComponent `json:"components,omitempty"`
+}
+
+// PredictionComponent is one model's contribution to an ensemble prediction
+type PredictionComponent struct {
+	ModelVersion string  `json:"model_version"`
+	PredictedCPU float64 `json:"predicted_cpu"`
+	Confidence   float64 `json:"confidence"`
+	Weight       float64 `json:"weight"`
+	RecentError  float64 `json:"recent_error"`
 }
 
 func NewPrediction(clusterID string, forecastTime time.Time, predictedCPU, confidence float64) *Prediction {
@@ -36,6 +50,7 @@ type PatternRecord struct {
 	AvgCPU      float64   `json:"avg_cpu"`
 	AvgMemory   float64   `json:"avg_memory"`
 	AvgLoad     float64   `json:"avg_load"`
+	CPUStdDev   float64   `json:"cpu_stddev"`
 	SampleCount int       `json:"sample_count"`
 	LastUpdated time.Time `json:"last_updated"`
 }
\ No newline at end of file
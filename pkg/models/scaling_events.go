@@ -22,6 +22,7 @@ type ScalingEvent struct {
 	PredictionUsed bool               `json:"prediction_used"`
 	Confidence     *float64           `json:"confidence,omitempty"`
 	Status         ScalingEventStatus `json:"status"`
+	InitiatedBy    *int               `json:"initiated_by,omitempty"`
 }
 
 func NewScalingEvent(decision ScalingDecision, status ScalingEventStatus) *ScalingEvent {
@@ -34,6 +35,7 @@ func NewScalingEvent(decision ScalingDecision, status ScalingEventStatus) *Scali
 		TriggerReason:  decision.Reason,
 		PredictionUsed:  decision.PredictionUsed,
 		Status:         status,
+		InitiatedBy:    decision.InitiatedBy,
 	}
 	if decision.Confidence > 0 {
 		event.Confidence = &decision.Confidence
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Longest window a single schedule firing may open
+const maxScheduleDurationMinutes = 7 * 24 * 60
+
+// ScalingSchedule temporarily overrides a cluster's server limits. Each time the
+// cron expression fires in the schedule's time zone, the override holds for DurationMinutes.
+type ScalingSchedule struct {
+	ID              string    `json:"id"`
+	ClusterID       string    `json:"cluster_id"`
+	Name            string    `json:"name"`
+	Cron            string    `json:"cron"`
+	Timezone        string    `json:"timezone"`
+	DurationMinutes int       `json:"duration_minutes"`
+	MinServers      *int      `json:"min_servers,omitempty"`
+	MaxServers      *int      `json:"max_servers,omitempty"`
+	DesiredServers  *int      `json:"desired_servers,omitempty"`
+	Enabled         bool      `json:"enabled"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func NewScalingSchedule(clusterID, name string) *ScalingSchedule {
+	now := time.Now()
+	return &ScalingSchedule{
+		ID:        NewUUID(),
+		ClusterID: clusterID,
+		Name:      name,
+		Timezone:  "UTC",
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Duration returns how long each firing keeps the override in place
+func (s *ScalingSchedule) Duration() time.Duration {
+	return time.Duration(s.DurationMinutes) * time.Minute
+}
+
+// Location resolves the schedule's time zone, defaulting to UTC
+func (s *ScalingSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// Validate checks everything except the cron expression, which is parsed by the scheduler
+func (s *ScalingSchedule) Validate() error {
+	if s.Name == "" {
+		return errors.New("name is required")
+	}
+	if s.Cron == "" {
+		return errors.New("cron is required")
+	}
+	if _, err := s.Location(); err != nil {
+		return fmt.Errorf("unknown timezone %q", s.Timezone)
+	}
+	if s.DurationMinutes < 1 || s.DurationMinutes > maxScheduleDurationMinutes {
+		return fmt.Errorf("duration_minutes must be between 1 and %d", maxScheduleDurationMinutes)
+	}
+
+	if s.MinServers == nil && s.MaxServers == nil && s.DesiredServers == nil {
+		return errors.New("at least one of min_servers, max_servers or desired_servers is required")
+	}
+	if s.DesiredServers != nil && (s.MinServers != nil || s.MaxServers != nil) {
+		return errors.New("desired_servers cannot be combined with min_servers or max_servers")
+	}
+	for _, limit := range []struct {
+		field string
+		value *int
+	}{
+		{"min_servers", s.MinServers},
+		{"max_servers", s.MaxServers},
+		{"desired_servers", s.DesiredServers},
+	} {
+		if limit.value != nil && *limit.value < 1 {
+			return fmt.Errorf("%s must be at least 1", limit.field)
+		}
+	}
+	if s.MinServers != nil && s.MaxServers != nil && *s.MinServers > *s.MaxServers {
+		return errors.New("min_servers must not exceed max_servers")
+	}
+
+	return nil
+}
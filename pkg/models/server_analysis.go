@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+type ServerStatus string
+
+const (
+	ServerStatusNormal ServerStatus = "normal"
+	ServerStatusHot    ServerStatus = "hot"
+	ServerStatusStale  ServerStatus = "stale"
+)
+
+// ServerAnalysis is a server's latest metrics and how it compares to the rest of its cluster
+type ServerAnalysis struct {
+	ServerID     string       `json:"server_id"`
+	CPUUsage     float64      `json:"cpu_usage"`
+	MemoryUsage  float64      `json:"memory_usage"`
+	RequestLoad  int          `json:"request_load"`
+	CPUDeviation float64      `json:"cpu_deviation"`
+	Status       ServerStatus `json:"status"`
+	LastSeen     time.Time    `json:"last_seen"`
+
+	// UnchangedSince is set while the server keeps reporting the same or all-zero metrics
+	UnchangedSince *time.Time `json:"unchanged_since,omitempty"`
+}
+
+// ServerReport is the per-server view of a cluster from one collection cycle.
+// CPUVariation is the coefficient of variation of CPU across servers with fresh metrics.
+type ServerReport struct {
+	ClusterID    string           `json:"cluster_id"`
+	Timestamp    time.Time        `json:"timestamp"`
+	Servers      []ServerAnalysis `json:"servers"`
+	AvgCPU       float64          `json:"avg_cpu"`
+	MaxCPU       float64          `json:"max_cpu"`
+	MinCPU       float64          `json:"min_cpu"`
+	CPUStdDev    float64          `json:"cpu_std_dev"`
+	CPUVariation float64          `json:"cpu_variation"`
+	Imbalanced   bool             `json:"imbalanced"`
+}
+
+// ServersWith returns the servers currently in status
+func (r *ServerReport) ServersWith(status ServerStatus) []ServerAnalysis {
+	var servers []ServerAnalysis
+	for _, s := range r.Servers {
+		if s.Status == status {
+			servers = append(servers, s)
+		}
+	}
+	return servers
+}
+
+// Server returns the named server's analysis, if the report has it
+func (r *ServerReport) Server(serverID string) (ServerAnalysis, bool) {
+	for _, s := range r.Servers {
+		if s.ServerID == serverID {
+			return s, true
+		}
+	}
+	return ServerAnalysis{}, false
+}
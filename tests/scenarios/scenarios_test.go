@@ -1,13 +1,24 @@
 package scenarios
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/OldStager01/cloud-autoscaler/internal/analyzer"
+	"github.com/OldStager01/cloud-autoscaler/internal/collector"
 	"github.com/OldStager01/cloud-autoscaler/internal/decision"
+	"github.com/OldStager01/cloud-autoscaler/internal/events"
+	"github.com/OldStager01/cloud-autoscaler/internal/orchestrator"
+	"github.com/OldStager01/cloud-autoscaler/internal/scaler"
+	"github.com/OldStager01/cloud-autoscaler/pkg/config"
+	"github.com/OldStager01/cloud-autoscaler/pkg/database"
+	"github.com/OldStager01/cloud-autoscaler/pkg/database/queries"
 	"github.com/OldStager01/cloud-autoscaler/pkg/models"
 )
 
@@ -192,3 +203,782 @@ func TestScenario_CollectorFailureAndRecovery(t *testing.T) {
 		})
 	}
 }
+
+// countingScaler records how often the pipeline asked it to scale, and by how much last time
+type countingScaler struct {
+	calls     atomic.Int32
+	lastCount atomic.Int32
+}
+
+func (s *countingScaler) ScaleUp(ctx context.Context, clusterID string, count int) (*scaler.ScaleResult, error) {
+	s.calls.Add(1)
+	s.lastCount.Store(int32(count))
+	return &scaler.ScaleResult{ClusterID: clusterID, Success: true}, nil
+}
+
+func (s *countingScaler) ScaleDown(ctx context.Context, clusterID string, count int) (*scaler.ScaleResult, error) {
+	s.calls.Add(1)
+	s.lastCount.Store(int32(count))
+	return &scaler.ScaleResult{ClusterID: clusterID, Success: true}, nil
+}
+
+func (s *countingScaler) GetClusterState(ctx context.Context, clusterID string) (*models.ClusterState, error) {
+	return &models.ClusterState{ClusterID: clusterID, ActiveServers: 4, TotalServers: 4}, nil
+}
+
+func (s *countingScaler) GetServer(ctx context.Context, serverID string) (*models.Server, error) {
+	return nil, nil
+}
+
+func (s *countingScaler) Close() error {
+	return nil
+}
+
+func TestScenario_ObserveModeNeverScales(t *testing.T) {
+	coll := collector.NewMockCollector(collector.MockCollectorConfig{BaseCPU: 99, Variance: 0.5})
+	coll.SetClusterServers("cluster-1", 4)
+	scal := &countingScaler{}
+
+	bus := events.NewEventBus(100)
+	recorded := bus.Subscribe(models.EventTypeDecisionRecorded)
+	wouldScale := bus.Subscribe(models.EventTypeWouldScale)
+
+	pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+		ClusterID:        "cluster-1",
+		CollectInterval:  time.Hour,
+		Collector:        coll,
+		Analyzer:         analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30}),
+		SustainedTracker: analyzer.NewSustainedTracker(),
+		DecisionEngine:   newScenarioEngine(),
+		Scaler:           scal,
+		EventPublisher:   events.NewPublisher(bus),
+		ObserveOnly:      true,
+	})
+	assert.NoError(t, pipeline.Start())
+	defer pipeline.Stop()
+
+	select {
+	case event := <-wouldScale:
+		data := event.Data.(map[string]interface{})
+		assert.Equal(t, models.ActionScaleUp, data["action"])
+		assert.Equal(t, 3, data["delta"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("no would_scale event")
+	}
+
+	select {
+	case event := <-recorded:
+		record := event.Data.(*models.DecisionRecord)
+		assert.Equal(t, models.OutcomeObserved, record.Outcome)
+		assert.True(t, record.IsEmergency)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no decision_recorded event")
+	}
+
+	assert.Zero(t, scal.calls.Load())
+}
+
+// staticFreezes reports the same open freeze windows on every cycle
+type staticFreezes []models.ActiveFreeze
+
+func (f staticFreezes) ActiveFreezes(ctx context.Context, clusterID string, now time.Time) ([]models.ActiveFreeze, error) {
+	return f, nil
+}
+
+func TestScenario_FreezeSuppressesScaling(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		allowEmergency bool
+		outcome        models.DecisionOutcome
+		reason         string
+		scalerCalls    int32
+	}{
+		{"blocks emergency scale-up", false, models.OutcomeFrozen, "freeze_active", 0},
+		{"allows emergency scale-up", true, models.OutcomeExecuted, "emergency_cpu_critical", 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			coll := collector.NewMockCollector(collector.MockCollectorConfig{BaseCPU: 99, Variance: 0.5})
+			coll.SetClusterServers("cluster-1", 4)
+			scal := &countingScaler{}
+
+			bus := events.NewEventBus(100)
+			recorded := bus.Subscribe(models.EventTypeDecisionRecorded)
+
+			freeze := models.NewFreezeWindow(1, "release")
+			freeze.AllowEmergency = tc.allowEmergency
+
+			pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+				ClusterID:        "cluster-1",
+				CollectInterval:  time.Hour,
+				Collector:        coll,
+				Analyzer:         analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30}),
+				SustainedTracker: analyzer.NewSustainedTracker(),
+				DecisionEngine:   newScenarioEngine(),
+				Scaler:           scal,
+				EventPublisher:   events.NewPublisher(bus),
+				Freezes:          staticFreezes{{FreezeWindow: *freeze, ActiveUntil: time.Now().Add(time.Hour)}},
+			})
+			assert.NoError(t, pipeline.Start())
+			defer pipeline.Stop()
+
+			select {
+			case event := <-recorded:
+				record := event.Data.(*models.DecisionRecord)
+				assert.Equal(t, models.ActionScaleUp, record.Action)
+				assert.True(t, record.IsEmergency)
+				assert.Equal(t, tc.outcome, record.Outcome)
+				assert.Equal(t, tc.reason, record.Reason)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no decision_recorded event")
+			}
+			assert.Equal(t, tc.scalerCalls, scal.calls.Load())
+		})
+	}
+}
+
+func TestScenario_ApprovalHoldsLargeScaleUp(t *testing.T) {
+	coll := collector.NewMockCollector(collector.MockCollectorConfig{BaseCPU: 90, Variance: 0.5})
+	coll.SetClusterServers("cluster-1", 4)
+	scal := &countingScaler{}
+
+	bus := events.NewEventBus(100)
+	created := bus.Subscribe(models.EventTypeScalingRequestCreated)
+	recorded := bus.Subscribe(models.EventTypeDecisionRecorded)
+
+	pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+		ClusterID:        "cluster-1",
+		CollectInterval:  time.Hour,
+		Collector:        coll,
+		Analyzer:         analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30}),
+		SustainedTracker: analyzer.NewSustainedTracker(),
+		DecisionEngine: decision.NewEngine(decision.Config{
+			EmergencyCPUThreshold: 95.0,
+			MinServers:            2,
+			MaxServers:            10,
+			MaxScaleStep:          3,
+			CPUHighThreshold:      80.0,
+			CPULowThreshold:       30.0,
+			SustainedHighDuration: time.Nanosecond,
+		}),
+		Scaler:         scal,
+		EventPublisher: events.NewPublisher(bus),
+		Approval: &models.ApprovalPolicy{
+			ServerHourlyCost: 2.5,
+			MaxHourlyCost:    2.0,
+			TimeoutSeconds:   3600,
+		},
+	})
+	assert.NoError(t, pipeline.Start())
+	defer pipeline.Stop()
+
+	var request *models.ScalingRequest
+	select {
+	case event := <-created:
+		request = event.Data.(*models.ScalingRequest)
+		assert.Equal(t, models.ScalingRequestPending, request.Status)
+		assert.Equal(t, 5, request.TargetServers)
+		assert.Equal(t, 2.5, request.AddedHourlyCost)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no scaling_request_created event")
+	}
+
+	select {
+	case event := <-recorded:
+		record := event.Data.(*models.DecisionRecord)
+		assert.Equal(t, models.OutcomePendingApproval, record.Outcome)
+		assert.Equal(t, request.ID, record.ScalingRequestID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no decision_recorded event")
+	}
+	assert.Zero(t, scal.calls.Load())
+	assert.Equal(t, request.ID, pipeline.PendingRequest().ID)
+
+	approved, err := pipeline.Approve(context.Background(), request.ID, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ScalingRequestApproved, approved.Status)
+	assert.Equal(t, models.OutcomeExecuted, approved.Outcome)
+	assert.Equal(t, 7, *approved.ResolvedBy)
+	assert.Equal(t, int32(1), scal.calls.Load())
+	assert.Equal(t, int32(1), scal.lastCount.Load())
+	assert.Nil(t, pipeline.PendingRequest())
+
+	_, err = pipeline.Approve(context.Background(), request.ID, 7)
+	assert.ErrorIs(t, err, orchestrator.ErrRequestNotPending)
+}
+
+// toggledFreezes blocks all scaling once frozen is set
+type toggledFreezes struct {
+	frozen atomic.Bool
+}
+
+func (f *toggledFreezes) ActiveFreezes(ctx context.Context, clusterID string, now time.Time) ([]models.ActiveFreeze, error) {
+	if !f.frozen.Load() {
+		return nil, nil
+	}
+	return []models.ActiveFreeze{{FreezeWindow: *models.NewFreezeWindow(1, "release"), ActiveUntil: now.Add(time.Hour)}}, nil
+}
+
+func TestScenario_ApprovalRespectsFreezeAndObserveMode(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		outcome models.DecisionOutcome
+		reason  string
+	}{
+		{"freeze opened while pending", models.OutcomeFrozen, "freeze_active"},
+		{"observe mode switched on while pending", models.OutcomeObserved, "approved"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			coll := collector.NewMockCollector(collector.MockCollectorConfig{BaseCPU: 90, Variance: 0.5})
+			coll.SetClusterServers("cluster-1", 4)
+			scal := &countingScaler{}
+			freezes := &toggledFreezes{}
+
+			bus := events.NewEventBus(100)
+			created := bus.Subscribe(models.EventTypeScalingRequestCreated)
+			recorded := bus.Subscribe(models.EventTypeDecisionRecorded)
+
+			pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+				ClusterID:        "cluster-1",
+				CollectInterval:  time.Hour,
+				Collector:        coll,
+				Analyzer:         analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30}),
+				SustainedTracker: analyzer.NewSustainedTracker(),
+				DecisionEngine: decision.NewEngine(decision.Config{
+					EmergencyCPUThreshold: 95.0,
+					MinServers:            2,
+					MaxServers:            10,
+					MaxScaleStep:          3,
+					CPUHighThreshold:      80.0,
+					CPULowThreshold:       30.0,
+					SustainedHighDuration: time.Nanosecond,
+				}),
+				Scaler:         scal,
+				EventPublisher: events.NewPublisher(bus),
+				Freezes:        freezes,
+				Approval:       &models.ApprovalPolicy{ServerHourlyCost: 2.5, MaxHourlyCost: 2.0, TimeoutSeconds: 3600},
+			})
+			assert.NoError(t, pipeline.Start())
+			defer pipeline.Stop()
+
+			var request *models.ScalingRequest
+			select {
+			case event := <-created:
+				request = event.Data.(*models.ScalingRequest)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no scaling_request_created event")
+			}
+			<-recorded
+
+			if tc.outcome == models.OutcomeFrozen {
+				freezes.frozen.Store(true)
+			} else {
+				pipeline.SetObserveOnly(true)
+			}
+
+			approved, err := pipeline.Approve(context.Background(), request.ID, 7)
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.ScalingRequestApproved, approved.Status)
+			assert.Equal(t, tc.outcome, approved.Outcome)
+			assert.Zero(t, scal.calls.Load())
+
+			select {
+			case event := <-recorded:
+				record := event.Data.(*models.DecisionRecord)
+				assert.Equal(t, tc.outcome, record.Outcome)
+				assert.Equal(t, tc.reason, record.Reason)
+				assert.Equal(t, request.ID, record.ScalingRequestID)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no decision_recorded event")
+			}
+		})
+	}
+}
+
+func TestScenario_PinReplacesAutomaticDecisions(t *testing.T) {
+	coll := collector.NewMockCollector(collector.MockCollectorConfig{BaseCPU: 99, Variance: 0.5})
+	coll.SetClusterServers("cluster-1", 4)
+	scal := &countingScaler{}
+
+	bus := events.NewEventBus(100)
+	completed := bus.Subscribe(models.EventTypeScalingComplete)
+
+	pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+		ClusterID:        "cluster-1",
+		CollectInterval:  time.Hour,
+		Collector:        coll,
+		Analyzer:         analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30}),
+		SustainedTracker: analyzer.NewSustainedTracker(),
+		DecisionEngine:   newScenarioEngine(),
+		Scaler:           scal,
+		EventPublisher:   events.NewPublisher(bus),
+	})
+	pipeline.SetPin(models.ClusterPin{DesiredServers: 5, Until: time.Now().Add(time.Hour), PinnedBy: 7})
+	assert.NoError(t, pipeline.Start())
+	defer pipeline.Stop()
+
+	// Emergency CPU would add 3 servers; the pin holds the cluster at 5 instead
+	select {
+	case event := <-completed:
+		scalingEvent := event.Data.(*models.ScalingEvent)
+		assert.Equal(t, "pinned", scalingEvent.TriggerReason)
+		assert.Equal(t, 5, scalingEvent.ServersAfter)
+		if assert.NotNil(t, scalingEvent.InitiatedBy) {
+			assert.Equal(t, 7, *scalingEvent.InitiatedBy)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no scaling_complete event")
+	}
+	assert.Equal(t, int32(1), scal.lastCount.Load())
+
+	assert.True(t, pipeline.ClearPin())
+	assert.Nil(t, pipeline.Pin())
+}
+
+func TestScenario_ManualScale(t *testing.T) {
+	scal := &countingScaler{}
+	pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+		ClusterID:      "cluster-1",
+		DecisionEngine: newScenarioEngine(),
+		Scaler:         scal,
+		EventPublisher: events.NewPublisher(events.NewEventBus(100)),
+	})
+
+	record, err := pipeline.ScaleTo(context.Background(), 2, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ActionScaleDown, record.Action)
+	assert.Equal(t, "manual_scale", record.Reason)
+	assert.Equal(t, models.OutcomeExecuted, record.Outcome)
+	assert.Equal(t, 7, *record.InitiatedBy)
+	assert.Equal(t, int32(2), scal.lastCount.Load())
+
+	record, err = pipeline.ScaleTo(context.Background(), 4, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.OutcomeMaintained, record.Outcome)
+	assert.Equal(t, int32(1), scal.calls.Load())
+}
+
+func TestScenario_ManualReversalsDoNotTriggerDamping(t *testing.T) {
+	engine := decision.NewEngine(decision.Config{
+		CooldownPeriod:          30 * time.Second,
+		ScaleDownCooldownPeriod: 30 * time.Second,
+		MinServers:              2,
+		MaxServers:              10,
+		CPULowThreshold:         30.0,
+		Flap:                    decision.FlapConfig{Enabled: true, Reversals: 3, CooldownMultiplier: 3, HysteresisMargin: 10},
+	})
+	pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+		ClusterID:      "cluster-1",
+		DecisionEngine: engine,
+		Scaler:         &countingScaler{},
+		EventPublisher: events.NewPublisher(events.NewEventBus(100)),
+	})
+
+	for _, target := range []int{6, 3, 6, 3, 6} {
+		record, err := pipeline.ScaleTo(context.Background(), target, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, models.OutcomeExecuted, record.Outcome)
+	}
+
+	assert.False(t, engine.Damping("cluster-1").Active, "operator reversals are not oscillation")
+	assert.Greater(t, engine.Decide(
+		&models.AnalyzedMetrics{ClusterID: "cluster-1", AvgCPU: 25, AvgMemory: 30, Trend: models.TrendStable},
+		nil, &models.ClusterState{ActiveServers: 6, TotalServers: 6},
+	).Trace.Cooldown.ScaleDownRemainingSeconds, 0.0, "manual scales still start the cooldowns")
+}
+
+// skewedCollector reports one busy server next to idle ones
+type skewedCollector struct{}
+
+func (skewedCollector) Collect(ctx context.Context, clusterID string) (*models.ClusterMetrics, error) {
+	return &models.ClusterMetrics{
+		ClusterID: clusterID,
+		Timestamp: time.Now(),
+		Servers: []models.ServerMetric{
+			{ServerID: "hot", CPUUsage: 99, MemoryUsage: 60, RequestLoad: 900},
+			{ServerID: "idle-1", CPUUsage: 18, MemoryUsage: 30, RequestLoad: 100},
+			{ServerID: "idle-2", CPUUsage: 22, MemoryUsage: 30, RequestLoad: 120},
+		},
+	}, nil
+}
+
+func (skewedCollector) HealthCheck(ctx context.Context) error { return nil }
+
+func (skewedCollector) Close() error { return nil }
+
+func TestScenario_HotServerAlerts(t *testing.T) {
+	bus := events.NewEventBus(100)
+	hot := bus.Subscribe(models.EventTypeServerHot)
+	imbalance := bus.Subscribe(models.EventTypeLoadImbalance)
+	recorded := bus.Subscribe(models.EventTypeDecisionRecorded)
+
+	pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+		ClusterID:        "cluster-1",
+		CollectInterval:  time.Hour,
+		Collector:        skewedCollector{},
+		Analyzer:         analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30}),
+		SustainedTracker: analyzer.NewSustainedTracker(),
+		ServerAnalyzer:   analyzer.NewServerAnalyzer(analyzer.ServerConfig{}),
+		DecisionEngine:   newScenarioEngine(),
+		Scaler:           &countingScaler{},
+		EventPublisher:   events.NewPublisher(bus),
+	})
+	assert.NoError(t, pipeline.Start())
+	defer pipeline.Stop()
+
+	select {
+	case event := <-hot:
+		server := event.Data.(models.ServerAnalysis)
+		assert.Equal(t, "hot", server.ServerID)
+		assert.Equal(t, models.SeverityWarning, event.Severity)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no server_hot event")
+	}
+
+	select {
+	case event := <-imbalance:
+		report := event.Data.(*models.ServerReport)
+		assert.True(t, report.Imbalanced)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no load_imbalance event")
+	}
+
+	// The cluster average looks normal, so the engine leaves it alone
+	select {
+	case event := <-recorded:
+		assert.Equal(t, models.ActionMaintain, event.Data.(*models.DecisionRecord).Action)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no decision_recorded event")
+	}
+
+	report := pipeline.Servers()
+	if assert.NotNil(t, report) {
+		assert.Len(t, report.Servers, 3)
+	}
+}
+
+// storedHistory serves the same stored metrics, newest first, to every warm start
+type storedHistory []queries.AggregatedMetricPoint
+
+func (h storedHistory) GetAggregated(ctx context.Context, clusterID string, from, to time.Time, bucketMinutes int) ([]queries.AggregatedMetricPoint, error) {
+	return h, nil
+}
+
+func TestScenario_WarmStartContinuesSustainedHigh(t *testing.T) {
+	now := time.Now()
+	stored := func(avgCPU, p95CPU float64) storedHistory {
+		var history storedHistory
+		for i := 0; i < 10; i++ {
+			p95 := p95CPU
+			history = append(history, queries.AggregatedMetricPoint{
+				Time: now.Add(-time.Duration(i) * time.Minute), ClusterID: "cluster-1", AvgCPU: avgCPU, AvgMemory: 50,
+				MaxCPU: p95CPU, P95CPU: &p95,
+			})
+		}
+		return history
+	}
+	hotTail := stored(60, 92)
+
+	for _, tc := range []struct {
+		name      string
+		history   orchestrator.HistorySource
+		statistic models.CPUStatistic
+		action    models.ScalingAction
+		reason    string
+	}{
+		{"cold start waits", nil, "", models.ActionMaintain, "within_normal_parameters"},
+		{"warm start scales up", stored(88, 88), "", models.ActionScaleUp, "sustained_high_cpu"},
+		// The stored average of 60 against a live 88 reads as a rising trend either way
+		{"warm start restores the configured statistic", hotTail, models.CPUStatisticP95, models.ActionScaleUp, "sustained_high_cpu_rising"},
+		{"average statistic restores no sustained run", hotTail, models.CPUStatisticAvg, models.ActionScaleUp, "cpu_warning_rising_trend"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			coll := collector.NewMockCollector(collector.MockCollectorConfig{BaseCPU: 88, Variance: 0.5})
+			coll.SetClusterServers("cluster-1", 4)
+
+			bus := events.NewEventBus(100)
+			recorded := bus.Subscribe(models.EventTypeDecisionRecorded)
+
+			analyzerCfg := analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30, MemoryLowThreshold: 40, CPUStatistic: tc.statistic}
+			pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+				ClusterID:        "cluster-1",
+				CollectInterval:  time.Hour,
+				Collector:        coll,
+				Analyzer:         analyzer.New(analyzerCfg),
+				SustainedTracker: analyzer.NewSustainedTracker(),
+				DecisionEngine:   newScenarioEngine(),
+				Scaler:           &countingScaler{},
+				EventPublisher:   events.NewPublisher(bus),
+				AnalyzerConfig:   analyzerCfg,
+				History:          tc.history,
+			})
+			assert.NoError(t, pipeline.Start())
+			defer pipeline.Stop()
+
+			select {
+			case event := <-recorded:
+				record := event.Data.(*models.DecisionRecord)
+				assert.Equal(t, tc.action, record.Action)
+				assert.Equal(t, tc.reason, record.Reason)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no decision_recorded event")
+			}
+		})
+	}
+}
+
+// untimedCollector reports a quiet cluster without a usable timestamp
+type untimedCollector struct{}
+
+func (untimedCollector) Collect(ctx context.Context, clusterID string) (*models.ClusterMetrics, error) {
+	metrics := &models.ClusterMetrics{ClusterID: clusterID, Timestamp: time.Now(), EstimatedTimestamp: true}
+	for _, id := range []string{"s1", "s2", "s3", "s4"} {
+		metrics.Servers = append(metrics.Servers, models.ServerMetric{ServerID: id, CPUUsage: 10, MemoryUsage: 20, RequestLoad: 50})
+	}
+	return metrics, nil
+}
+
+func (untimedCollector) HealthCheck(ctx context.Context) error { return nil }
+
+func (untimedCollector) Close() error { return nil }
+
+func TestScenario_DataQualityPolicies(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		policy  models.DataQualityPolicy
+		applied models.DataQualityPolicy
+		decided bool
+	}{
+		{"skip drops the cycle", models.DataQualitySkip, models.DataQualitySkip, false},
+		{"hold last without a good analysis skips", models.DataQualityHoldLast, models.DataQualitySkip, false},
+		{"block scale-down suppresses the scale-down", models.DataQualityBlockScaleDown, models.DataQualityBlockScaleDown, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			scal := &countingScaler{}
+			bus := events.NewEventBus(100)
+			quality := bus.Subscribe(models.EventTypeDataQuality)
+			recorded := bus.Subscribe(models.EventTypeDecisionRecorded)
+			collected := bus.Subscribe(models.EventTypeMetricCollected)
+			a := analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30})
+			tracker := analyzer.NewSustainedTracker()
+
+			pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+				ClusterID:        "cluster-1",
+				CollectInterval:  time.Hour,
+				Collector:        untimedCollector{},
+				Analyzer:         a,
+				SustainedTracker: tracker,
+				DecisionEngine: decision.NewEngine(decision.Config{
+					MinServers:   2,
+					MaxServers:   10,
+					MaxScaleStep: 3,
+					TargetCPU:    70,
+					ScalingMode:  models.ScalingModeTargetTracking,
+				}),
+				Scaler:         scal,
+				EventPublisher: events.NewPublisher(bus),
+				DataQuality: collector.NewQualityChecker(collector.QualityConfig{
+					Policy:           tc.policy,
+					CheckServerCount: true,
+				}),
+			})
+			assert.NoError(t, pipeline.Start())
+			defer pipeline.Stop()
+
+			select {
+			case event := <-quality:
+				report := event.Data.(*models.DataQualityReport)
+				assert.Equal(t, tc.policy, report.Policy)
+				assert.Equal(t, tc.applied, report.Applied)
+				assert.Len(t, report.Issues, 1)
+				assert.Equal(t, models.DataQualityMissingTimestamp, report.Issues[0].Type)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no data_quality event")
+			}
+
+			select {
+			case event := <-recorded:
+				assert.True(t, tc.decided, "skipped cycles record no decision")
+				record := event.Data.(*models.DecisionRecord)
+				assert.Equal(t, models.ActionScaleDown, record.Action)
+				assert.Equal(t, models.OutcomeDataQualityBlocked, record.Outcome)
+				assert.Equal(t, "data_quality_blocked", record.Reason)
+			case <-time.After(200 * time.Millisecond):
+				assert.False(t, tc.decided, "no decision_recorded event")
+			}
+
+			assert.Zero(t, scal.calls.Load())
+			assert.Empty(t, a.GetHistory("cluster-1"), "failing cycles stay out of the history")
+			assert.Zero(t, tracker.GetLowDuration("cluster-1"), "failing cycles aren't tracked as sustained")
+			assert.Empty(t, collected, "failing metrics aren't published for storage")
+		})
+	}
+}
+
+func TestScenario_ClusterOverridesValidatedAgainstGlobalConfig(t *testing.T) {
+	floatPtr := func(v float64) *float64 { return &v }
+	cfg := &config.Config{}
+	cfg.Analyzer.Thresholds = config.ThresholdConfig{CPUHigh: 80, CPULow: 30, MemoryHigh: 85, MemoryLow: 40}
+	orch := orchestrator.New(cfg, &database.DB{})
+
+	cluster := models.NewCluster("overrides", 2, 10, nil)
+	assert.NoError(t, orch.ValidateCluster(cluster))
+
+	// Fine on its own, but above the global cpu_high_threshold once merged
+	cluster.Config = &models.ClusterConfig{CPULowThreshold: floatPtr(85)}
+	assert.NoError(t, cluster.Config.Validate())
+	assert.ErrorContains(t, orch.ValidateCluster(cluster), "cpu_high_threshold")
+
+	cluster.Config.CPUHighThreshold = floatPtr(95)
+	assert.NoError(t, orch.ValidateCluster(cluster))
+}
+
+// storedDecisions keeps the decision records a pipeline writes
+type storedDecisions struct {
+	mu      sync.Mutex
+	records []*models.DecisionRecord
+}
+
+func (s *storedDecisions) Insert(ctx context.Context, record *models.DecisionRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *storedDecisions) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+func TestScenario_DecisionsStoredWhenEventBusIsFull(t *testing.T) {
+	coll := collector.NewMockCollector(collector.MockCollectorConfig{BaseCPU: 50, Variance: 0.5})
+	coll.SetClusterServers("cluster-1", 4)
+
+	// Nobody reads the subscription, so every event after the first is dropped
+	bus := events.NewEventBus(1)
+	bus.Subscribe(models.EventTypeDecisionRecorded)
+
+	store := &storedDecisions{}
+	pipeline := orchestrator.NewPipeline(orchestrator.PipelineConfig{
+		ClusterID:        "cluster-1",
+		CollectInterval:  time.Hour,
+		Collector:        coll,
+		Analyzer:         analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30}),
+		SustainedTracker: analyzer.NewSustainedTracker(),
+		DecisionEngine:   newScenarioEngine(),
+		Scaler:           &countingScaler{},
+		EventPublisher:   events.NewPublisher(bus),
+		Decisions:        store,
+	})
+	assert.NoError(t, pipeline.Start())
+	defer pipeline.Stop()
+
+	for _, target := range []int{5, 6, 4} {
+		_, err := pipeline.ScaleTo(context.Background(), target, 1)
+		assert.NoError(t, err)
+	}
+
+	assert.Eventually(t, func() bool { return store.count() == 4 }, 5*time.Second, 10*time.Millisecond,
+		"the cycle's decision and every manual scale are stored")
+}
+
+// storedRequests keeps a copy of every scaling request saved, failing when fail is set
+type storedRequests struct {
+	mu    sync.Mutex
+	saves []models.ScalingRequest
+	fail  bool
+}
+
+func (s *storedRequests) Save(ctx context.Context, request *models.ScalingRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return errors.New("database unavailable")
+	}
+	s.saves = append(s.saves, *request)
+	return nil
+}
+
+func (s *storedRequests) statuses() []models.ScalingRequestStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]models.ScalingRequestStatus, len(s.saves))
+	for i, saved := range s.saves {
+		statuses[i] = saved.Status
+	}
+	return statuses
+}
+
+func TestScenario_ScalingRequestsStoredByPipeline(t *testing.T) {
+	newPipeline := func(bus *events.EventBus, decisions *storedDecisions, requests *storedRequests) *orchestrator.Pipeline {
+		coll := collector.NewMockCollector(collector.MockCollectorConfig{BaseCPU: 90, Variance: 0.5})
+		coll.SetClusterServers("cluster-1", 4)
+		return orchestrator.NewPipeline(orchestrator.PipelineConfig{
+			ClusterID:        "cluster-1",
+			CollectInterval:  time.Hour,
+			Collector:        coll,
+			Analyzer:         analyzer.New(analyzer.Config{CPUHighThreshold: 80, CPULowThreshold: 30}),
+			SustainedTracker: analyzer.NewSustainedTracker(),
+			DecisionEngine: decision.NewEngine(decision.Config{
+				EmergencyCPUThreshold: 95.0,
+				MinServers:            2,
+				MaxServers:            10,
+				MaxScaleStep:          3,
+				CPUHighThreshold:      80.0,
+				CPULowThreshold:       30.0,
+				SustainedHighDuration: time.Nanosecond,
+			}),
+			Scaler:         &countingScaler{},
+			EventPublisher: events.NewPublisher(bus),
+			Approval:       &models.ApprovalPolicy{ServerHourlyCost: 2.5, MaxHourlyCost: 2.0, TimeoutSeconds: 3600},
+			Decisions:      decisions,
+			Requests:       requests,
+		})
+	}
+
+	t.Run("stored when the event bus is full", func(t *testing.T) {
+		// Nobody reads the subscription, so every event after the first is dropped
+		bus := events.NewEventBus(1)
+		bus.SubscribeAll()
+
+		requests := &storedRequests{}
+		pipeline := newPipeline(bus, &storedDecisions{}, requests)
+		assert.NoError(t, pipeline.Start())
+		defer pipeline.Stop()
+
+		assert.Eventually(t, func() bool { return pipeline.PendingRequest() != nil }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []models.ScalingRequestStatus{models.ScalingRequestPending}, requests.statuses())
+
+		_, err := pipeline.Reject(context.Background(), pipeline.PendingRequest().ID, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ScalingRequestStatus{
+			models.ScalingRequestPending, models.ScalingRequestRejected,
+		}, requests.statuses())
+	})
+
+	t.Run("decision fails when the request can't be stored", func(t *testing.T) {
+		bus := events.NewEventBus(100)
+		created := bus.Subscribe(models.EventTypeScalingRequestCreated)
+
+		decisions := &storedDecisions{}
+		pipeline := newPipeline(bus, decisions, &storedRequests{fail: true})
+		assert.NoError(t, pipeline.Start())
+		defer pipeline.Stop()
+
+		assert.Eventually(t, func() bool { return decisions.count() == 1 }, 5*time.Second, 10*time.Millisecond)
+		decisions.mu.Lock()
+		record := decisions.records[0]
+		decisions.mu.Unlock()
+		assert.Equal(t, models.OutcomeFailed, record.Outcome)
+		assert.Contains(t, record.Error, "database unavailable")
+		assert.Empty(t, record.ScalingRequestID)
+		assert.Nil(t, pipeline.PendingRequest())
+		assert.Empty(t, created, "an unstored request is never announced")
+	})
+}
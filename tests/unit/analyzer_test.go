@@ -1,6 +1,7 @@
 package unit
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -184,3 +185,348 @@ func TestSustainedTracker_Reset(t *testing.T) {
 	duration := tracker.GetHighDuration("test-cluster")
 	assert.Zero(t, duration, "expected duration to be 0 after reset")
 }
+
+func TestSustainedTracker_Memory(t *testing.T) {
+	tracker := analyzer.NewSustainedTracker()
+	cfg := analyzer.Config{CPUHighThreshold: 80.0, CPULowThreshold: 30.0, MemoryHighThreshold: 85.0, MemoryLowThreshold: 40.0}
+
+	analyzed := &models.AnalyzedMetrics{AvgCPU: 40.0, AvgMemory: 92.0}
+	tracker.Update("test-cluster", analyzed, cfg)
+
+	require.NotNil(t, analyzed.SustainedMemoryHighAt, "expected SustainedMemoryHighAt to be set")
+	assert.Nil(t, analyzed.SustainedHighAt, "CPU is not high")
+
+	analyzed = &models.AnalyzedMetrics{AvgCPU: 40.0, AvgMemory: 30.0}
+	tracker.Update("test-cluster", analyzed, cfg)
+
+	assert.Nil(t, analyzed.SustainedMemoryHighAt)
+	require.NotNil(t, analyzed.SustainedMemoryLowAt, "expected SustainedMemoryLowAt to be set")
+	assert.Zero(t, tracker.GetMemoryHighDuration("test-cluster"))
+}
+
+// analyzeCPU feeds the analyzer one sample per value and returns the last result
+func analyzeCPU(a *analyzer.Analyzer, clusterID string, values ...float64) *models.AnalyzedMetrics {
+	var result *models.AnalyzedMetrics
+	for _, cpu := range values {
+		result = a.Analyze(&models.ClusterMetrics{
+			ClusterID: clusterID,
+			Timestamp: time.Now(),
+			Servers:   []models.ServerMetric{{ServerID: "s1", CPUUsage: cpu}},
+		})
+	}
+	return result
+}
+
+func newAnomalyAnalyzer(detector string) *analyzer.Analyzer {
+	return analyzer.New(analyzer.Config{
+		CPUHighThreshold: 80.0,
+		CPULowThreshold:  30.0,
+		TrendWindow:      5 * time.Minute,
+		SpikeThreshold:   50.0,
+		Anomaly:          analyzer.AnomalyConfig{Detector: detector},
+	})
+}
+
+func TestAnalyzer_DetectAnomaly(t *testing.T) {
+	quiet := func(level float64) []float64 {
+		values := make([]float64, 20)
+		for i := range values {
+			values[i] = level + float64(i%3) - 1
+		}
+		return values
+	}
+	ramp := make([]float64, 20)
+	for i := range ramp {
+		ramp[i] = 30 + 2*float64(i)
+	}
+
+	tests := []struct {
+		name        string
+		detector    string
+		baseline    []float64
+		current     float64
+		wantAnomaly bool
+	}{
+		{"zscore flags a surge", analyzer.DetectorZScore, quiet(40), 70, true},
+		{"mad flags a surge", analyzer.DetectorMAD, quiet(40), 70, true},
+		{"ewma flags a surge", analyzer.DetectorEWMA, quiet(40), 70, true},
+		{"zscore ignores normal variation", analyzer.DetectorZScore, quiet(40), 41, false},
+		{"mad ignores normal variation", analyzer.DetectorMAD, quiet(40), 41, false},
+		{"ewma ignores normal variation", analyzer.DetectorEWMA, quiet(40), 41, false},
+		{"small rise from low base is not an anomaly", analyzer.DetectorZScore, quiet(10), 16, false},
+		{"drop is not an anomaly", analyzer.DetectorZScore, quiet(60), 20, false},
+		{"ewma catches slow surge", analyzer.DetectorEWMA, ramp, 70, true},
+		{"zscore misses slow surge", analyzer.DetectorZScore, ramp, 70, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAnomalyAnalyzer(tt.detector)
+			analyzeCPU(a, "test-cluster", tt.baseline...)
+			result := analyzeCPU(a, "test-cluster", tt.current)
+
+			assert.Equal(t, tt.wantAnomaly, result.IsAnomaly, "score %.2f against baseline %.1f",
+				result.AnomalyScore, result.AnomalyBaseline)
+			assert.Equal(t, tt.detector, result.AnomalyDetector)
+		})
+	}
+}
+
+func TestAnalyzer_DetectAnomaly_LowBaseSpike(t *testing.T) {
+	lowBase := []float64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10}
+
+	t.Run("without a detector the relative check fires", func(t *testing.T) {
+		a := newAnomalyAnalyzer("")
+		analyzeCPU(a, "test-cluster", lowBase...)
+		result := analyzeCPU(a, "test-cluster", 16)
+
+		assert.True(t, result.HasSpike, "relative spike check fires on 10% to 16%")
+	})
+
+	t.Run("detector replaces the relative check", func(t *testing.T) {
+		a := newAnomalyAnalyzer(analyzer.DetectorZScore)
+		analyzeCPU(a, "test-cluster", lowBase...)
+		result := analyzeCPU(a, "test-cluster", 16)
+
+		assert.False(t, result.HasSpike)
+		assert.False(t, result.IsAnomaly)
+		assert.Equal(t, 10.0, result.AnomalyBaseline)
+	})
+
+	t.Run("spikes clear the minimum deviation while the baseline builds", func(t *testing.T) {
+		a := newAnomalyAnalyzer(analyzer.DetectorZScore)
+		result := analyzeCPU(a, "test-cluster", 10, 10, 10, 16)
+		assert.False(t, result.HasSpike)
+
+		result = analyzeCPU(a, "test-cluster", 40)
+		assert.True(t, result.HasSpike)
+	})
+}
+
+func TestAnalyzer_DetectAnomaly_NeedsBaseline(t *testing.T) {
+	a := newAnomalyAnalyzer(analyzer.DetectorZScore)
+	result := analyzeCPU(a, "test-cluster", 40, 40, 40, 90)
+
+	assert.False(t, result.IsAnomaly)
+	assert.Empty(t, result.AnomalyDetector)
+
+	disabled := newAnomalyAnalyzer("")
+	result = analyzeCPU(disabled, "test-cluster", 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 95)
+	assert.False(t, result.IsAnomaly)
+	assert.Empty(t, result.AnomalyDetector)
+}
+
+func TestNewAnomalyDetector_UnknownType(t *testing.T) {
+	_, err := analyzer.NewAnomalyDetector(analyzer.AnomalyConfig{Detector: "iforest"})
+	require.ErrorIs(t, err, analyzer.ErrUnknownDetector)
+}
+
+func serverMetrics(at time.Time, cpus ...float64) *models.ClusterMetrics {
+	metrics := &models.ClusterMetrics{ClusterID: "test-cluster", Timestamp: at}
+	for i, cpu := range cpus {
+		metrics.Servers = append(metrics.Servers, models.ServerMetric{
+			ServerID:    fmt.Sprintf("s%d", i+1),
+			CPUUsage:    cpu,
+			MemoryUsage: cpu / 2,
+			RequestLoad: int(cpu) * 10,
+		})
+	}
+	return metrics
+}
+
+func TestServerAnalyzer_HotServerAndImbalance(t *testing.T) {
+	tests := []struct {
+		name           string
+		cpus           []float64
+		wantHot        []string
+		wantImbalanced bool
+	}{
+		{"one server pegged while the rest idle", []float64{100, 20, 20, 20}, []string{"s1"}, true},
+		{"even load", []float64{60, 62, 58, 61}, nil, false},
+		{"all servers busy", []float64{96, 95, 97, 94}, nil, false},
+		{"uneven but quiet", []float64{40, 5, 5, 5}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := analyzer.NewServerAnalyzer(analyzer.ServerConfig{})
+			report := a.Analyze(serverMetrics(time.Now(), tt.cpus...))
+
+			var hot []string
+			for _, s := range report.ServersWith(models.ServerStatusHot) {
+				hot = append(hot, s.ServerID)
+			}
+			assert.Equal(t, tt.wantHot, hot)
+			assert.Equal(t, tt.wantImbalanced, report.Imbalanced, "variation %.2f", report.CPUVariation)
+			assert.Len(t, report.Servers, len(tt.cpus))
+		})
+	}
+}
+
+func TestServerAnalyzer_StaleMetrics(t *testing.T) {
+	a := analyzer.NewServerAnalyzer(analyzer.ServerConfig{StaleAfter: time.Minute})
+	start := time.Now()
+
+	// s1 repeats its reading, s2 keeps moving, s3 reports zeros
+	for i := 0; i < 4; i++ {
+		at := start.Add(time.Duration(i) * 30 * time.Second)
+		a.Analyze(serverMetrics(at, 50, 40+float64(i), 0))
+	}
+	report := a.Analyze(serverMetrics(start.Add(2*time.Minute), 50, 45, 0))
+
+	stale := report.ServersWith(models.ServerStatusStale)
+	require.Len(t, stale, 2)
+	assert.Equal(t, "s1", stale[0].ServerID)
+	assert.Equal(t, start, *stale[0].UnchangedSince)
+	assert.Equal(t, "s3", stale[1].ServerID)
+
+	s2, ok := report.Server("s2")
+	require.True(t, ok)
+	assert.Equal(t, models.ServerStatusNormal, s2.Status)
+	assert.Nil(t, s2.UnchangedSince)
+
+	// Stale servers don't count towards the spread
+	assert.InDelta(t, 45.0, report.AvgCPU, 0.01)
+
+	// A fresh reading clears the stale status
+	report = a.Analyze(serverMetrics(start.Add(150*time.Second), 52, 45, 0))
+	s1, _ := report.Server("s1")
+	assert.Equal(t, models.ServerStatusNormal, s1.Status)
+}
+
+// minuteSnapshots returns one snapshot per minute ending now, oldest first
+func minuteSnapshots(cpus ...float64) []analyzer.Snapshot {
+	now := time.Now()
+	snapshots := make([]analyzer.Snapshot, len(cpus))
+	for i, cpu := range cpus {
+		snapshots[i] = analyzer.Snapshot{
+			Timestamp:  now.Add(-time.Duration(len(cpus)-1-i) * time.Minute),
+			AvgCPU:     cpu,
+			AvgMemory:  50,
+			ScalingCPU: cpu,
+		}
+	}
+	return snapshots
+}
+
+func TestAnalyzer_Restore(t *testing.T) {
+	a := newTestAnalyzer()
+	a.Restore("test-cluster", minuteSnapshots(40, 45, 50, 60, 70))
+
+	assert.Len(t, a.GetHistory("test-cluster"), 5)
+
+	result := analyzeCPU(a, "test-cluster", 75)
+	assert.Equal(t, models.TrendRising, result.Trend, "restored history feeds the first trend")
+
+	// Live history wins over a later restore
+	a.Restore("test-cluster", minuteSnapshots(10))
+	assert.Len(t, a.GetHistory("test-cluster"), 6)
+}
+
+func TestAnalyzer_Evaluate(t *testing.T) {
+	a := newTestAnalyzer()
+	analyzeCPU(a, "test-cluster", 40, 45, 50)
+
+	metrics := &models.ClusterMetrics{
+		ClusterID: "test-cluster",
+		Timestamp: time.Now(),
+		Servers:   []models.ServerMetric{{ServerID: "s1", CPUUsage: 90, MemoryUsage: 50}},
+	}
+	result := a.Evaluate(metrics)
+
+	assert.Equal(t, 90.0, result.AvgCPU)
+	assert.Equal(t, models.TrendRising, result.Trend, "evaluated metrics are scored against the history")
+	assert.Len(t, a.GetHistory("test-cluster"), 3, "evaluated metrics aren't recorded")
+
+	result = analyzeCPU(a, "test-cluster", 50)
+	assert.False(t, result.HasSpike, "later cycles compare against recorded metrics only")
+}
+
+func TestSustainedTracker_Restore(t *testing.T) {
+	cfg := analyzer.Config{CPUHighThreshold: 80.0, CPULowThreshold: 30.0, MemoryLowThreshold: 40.0}
+	snapshots := minuteSnapshots(20, 50, 85, 90, 88, 92)
+
+	tracker := analyzer.NewSustainedTracker()
+	tracker.Restore("test-cluster", snapshots, cfg, 2*time.Minute)
+
+	analyzed := &models.AnalyzedMetrics{AvgCPU: 91, AvgMemory: 50}
+	tracker.Update("test-cluster", analyzed, cfg)
+
+	require.NotNil(t, analyzed.SustainedHighAt)
+	assert.Equal(t, snapshots[2].Timestamp, *analyzed.SustainedHighAt)
+	assert.Nil(t, analyzed.SustainedLowAt, "low run ended before the latest snapshot")
+
+	// A gap in the stored metrics breaks the run
+	gapped := minuteSnapshots(85, 90, 88, 92)
+	gapped[0].Timestamp = gapped[0].Timestamp.Add(-10 * time.Minute)
+
+	tracker = analyzer.NewSustainedTracker()
+	tracker.Restore("test-cluster", gapped, cfg, 2*time.Minute)
+	analyzed = &models.AnalyzedMetrics{AvgCPU: 91, AvgMemory: 50}
+	tracker.Update("test-cluster", analyzed, cfg)
+
+	require.NotNil(t, analyzed.SustainedHighAt)
+	assert.Equal(t, gapped[1].Timestamp, *analyzed.SustainedHighAt)
+}
+
+func TestAnalyzer_CPUStatistic(t *testing.T) {
+	servers := make([]models.ServerMetric, 10)
+	for i := range servers {
+		servers[i] = models.ServerMetric{ServerID: fmt.Sprintf("s%d", i), CPUUsage: 40}
+	}
+	servers[8].CPUUsage = 90
+	servers[9].CPUUsage = 90
+	metrics := &models.ClusterMetrics{ClusterID: "test-cluster", Timestamp: time.Now(), Servers: servers}
+
+	avg := newTestAnalyzer().Analyze(metrics)
+	assert.Equal(t, models.ThresholdNormal, avg.CPUStatus)
+	assert.Equal(t, avg.AvgCPU, avg.SignalCPU())
+
+	a := analyzer.New(analyzer.Config{
+		CPUHighThreshold: 80.0,
+		CPULowThreshold:  30.0,
+		TrendWindow:      5 * time.Minute,
+		SpikeThreshold:   50.0,
+		CPUStatistic:     models.CPUStatisticP90,
+	})
+	result := a.Analyze(metrics)
+
+	assert.Equal(t, 50.0, result.AvgCPU)
+	assert.Equal(t, 90.0, result.P90)
+	assert.Equal(t, 90.0, result.SignalCPU())
+	assert.Equal(t, models.ThresholdWarning, result.CPUStatus, "the tail drives the threshold")
+}
+
+func TestAnalyzer_PercentileWindow(t *testing.T) {
+	now := time.Now()
+	cycle := func(at time.Time, cpu ...float64) *models.ClusterMetrics {
+		m := &models.ClusterMetrics{ClusterID: "test-cluster", Timestamp: at}
+		for i, v := range cpu {
+			m.Servers = append(m.Servers, models.ServerMetric{ServerID: fmt.Sprintf("s%d", i), CPUUsage: v})
+		}
+		return m
+	}
+	newAnalyzer := func(window time.Duration) *analyzer.Analyzer {
+		return analyzer.New(analyzer.Config{
+			CPUHighThreshold: 80.0,
+			CPULowThreshold:  30.0,
+			TrendWindow:      5 * time.Minute,
+			SpikeThreshold:   50.0,
+			CPUStatistic:     models.CPUStatisticMax,
+			PercentileWindow: window,
+		})
+	}
+
+	perCycle := newAnalyzer(0)
+	perCycle.Analyze(cycle(now.Add(-10*time.Minute), 40, 95))
+	perCycle.Analyze(cycle(now.Add(-time.Minute), 40, 92))
+	assert.Equal(t, 45.0, perCycle.Analyze(cycle(now, 40, 45)).SignalCPU())
+
+	windowed := newAnalyzer(5 * time.Minute)
+	windowed.Analyze(cycle(now.Add(-10*time.Minute), 40, 95))
+	windowed.Analyze(cycle(now.Add(-time.Minute), 40, 92))
+	result := windowed.Analyze(cycle(now, 40, 45))
+
+	assert.Equal(t, 92.0, result.SignalCPU(), "readings older than the window are dropped")
+	assert.Equal(t, models.ThresholdWarning, result.CPUStatus)
+}
@@ -31,8 +31,10 @@ func validConfig() *config.Config {
 		},
 		Analyzer: config.AnalyzerConfig{
 			Thresholds: config.ThresholdConfig{
-				CPUHigh: 80.0,
-				CPULow:  30.0,
+				CPUHigh:    80.0,
+				CPULow:     30.0,
+				MemoryHigh: 85.0,
+				MemoryLow:  40.0,
 			},
 		},
 		Decision: config.DecisionConfig{
@@ -42,7 +44,8 @@ func validConfig() *config.Config {
 			CooldownPeriod: 30 * time.Second,
 		},
 		API: config.APIConfig{
-			Port: 8080,
+			Port:      8080,
+			RateLimit: 100,
 		},
 	}
 }
@@ -86,6 +89,42 @@ func TestConfig_Validate(t *testing.T) {
 			expectErr:   true,
 			errContains: "timeout must be less than",
 		},
+		{
+			name: "invalid predictor confidence",
+			modifyFunc: func(c *config.Config) {
+				c.Predictor.Enabled = true
+				c.Predictor.ForecastWindow = 15 * time.Minute
+				c.Predictor.MinConfidence = 1.5
+			},
+			expectErr:   true,
+			errContains: "predictor.min_confidence must be between 0 and 1",
+		},
+		{
+			name: "unknown anomaly detector",
+			modifyFunc: func(c *config.Config) {
+				c.Analyzer.Anomaly = config.AnomalyConfig{
+					Detector: "iforest", BaselineSamples: 20, MinSamples: 10, Sensitivity: 3, MinDeviation: 10, EWMAAlpha: 0.3,
+				}
+			},
+			expectErr:   true,
+			errContains: "analyzer.anomaly.detector must be one of",
+		},
+		{
+			name: "unknown cpu statistic",
+			modifyFunc: func(c *config.Config) {
+				c.Analyzer.CPUStatistic = "p75"
+			},
+			expectErr:   true,
+			errContains: "analyzer.cpu_statistic must be one of",
+		},
+		{
+			name: "unknown data-quality policy",
+			modifyFunc: func(c *config.Config) {
+				c.Collector.Quality = config.QualityConfig{Enabled: true, Policy: "retry", StaleIntervals: 3}
+			},
+			expectErr:   true,
+			errContains: "collector.quality.policy must be one of",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/collector"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+func TestQualityChecker_Check(t *testing.T) {
+	now := time.Now()
+	servers := func(cpu ...float64) []models.ServerMetric {
+		metrics := make([]models.ServerMetric, len(cpu))
+		for i, v := range cpu {
+			metrics[i] = models.ServerMetric{ServerID: string(rune('a' + i)), CPUUsage: v, MemoryUsage: 50, RequestLoad: 100}
+		}
+		return metrics
+	}
+	state := &models.ClusterState{ActiveServers: 3, TotalServers: 4, ProvisioningCnt: 1}
+
+	tests := []struct {
+		name     string
+		metrics  *models.ClusterMetrics
+		state    *models.ClusterState
+		expected []models.DataQualityIssueType
+	}{
+		{
+			name:    "clean metrics",
+			metrics: &models.ClusterMetrics{Timestamp: now.Add(-5 * time.Second), Servers: servers(40, 50, 60)},
+			state:   state,
+		},
+		{
+			name:    "provisioning server already reporting",
+			metrics: &models.ClusterMetrics{Timestamp: now, Servers: servers(40, 50, 60, 10)},
+			state:   state,
+		},
+		{
+			name:     "stale timestamp",
+			metrics:  &models.ClusterMetrics{Timestamp: now.Add(-time.Minute), Servers: servers(40, 50, 60)},
+			state:    state,
+			expected: []models.DataQualityIssueType{models.DataQualityStaleTimestamp},
+		},
+		{
+			name:     "estimated timestamp",
+			metrics:  &models.ClusterMetrics{Timestamp: now, EstimatedTimestamp: true, Servers: servers(40, 50, 60)},
+			state:    state,
+			expected: []models.DataQualityIssueType{models.DataQualityMissingTimestamp},
+		},
+		{
+			name:     "no servers",
+			metrics:  &models.ClusterMetrics{Timestamp: now},
+			state:    state,
+			expected: []models.DataQualityIssueType{models.DataQualityNoServers},
+		},
+		{
+			name:     "servers missing",
+			metrics:  &models.ClusterMetrics{Timestamp: now, Servers: servers(40, 50)},
+			state:    state,
+			expected: []models.DataQualityIssueType{models.DataQualityServerCount},
+		},
+		{
+			name:    "count not checked without state",
+			metrics: &models.ClusterMetrics{Timestamp: now, Servers: servers(40)},
+		},
+		{
+			name:    "out of range values",
+			metrics: &models.ClusterMetrics{Timestamp: now, Servers: servers(40, 140, math.NaN())},
+			state:   state,
+			expected: []models.DataQualityIssueType{
+				models.DataQualityOutOfRange, models.DataQualityOutOfRange,
+			},
+		},
+	}
+
+	checker := collector.NewQualityChecker(collector.QualityConfig{StaleIntervals: 3, CheckServerCount: true})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checker.Check(tt.metrics, tt.state, 10*time.Second, now)
+
+			var types []models.DataQualityIssueType
+			for _, issue := range issues {
+				types = append(types, issue.Type)
+			}
+			assert.Equal(t, tt.expected, types)
+		})
+	}
+}
+
+func TestQualityChecker_Defaults(t *testing.T) {
+	checker := collector.NewQualityChecker(collector.QualityConfig{Policy: "retry"})
+
+	assert.Equal(t, models.DataQualityBlockScaleDown, checker.Policy())
+	assert.Equal(t, 30*time.Second, checker.MaxAge(10*time.Second))
+
+	issues := checker.Check(&models.ClusterMetrics{
+		Timestamp: time.Now(),
+		Servers:   []models.ServerMetric{{ServerID: "s1", CPUUsage: 50, RequestLoad: -1}},
+	}, nil, 10*time.Second, time.Now())
+	require.Len(t, issues, 1)
+	assert.Equal(t, "s1", issues[0].ServerID)
+}
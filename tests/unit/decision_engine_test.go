@@ -128,6 +128,18 @@ func TestEngine_Decide(t *testing.T) {
 			state:          &models.ClusterState{ActiveServers: 5, TotalServers: 5},
 			expectedAction: models.ActionScaleUp,
 		},
+		{
+			name: "scale up on anomaly detected",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID:    "test-cluster",
+				AvgCPU:       65.0,
+				CPUStatus:    models.ThresholdNormal,
+				IsAnomaly:    true,
+				AnomalyScore: 4.2,
+			},
+			state:          &models.ClusterState{ActiveServers: 5, TotalServers: 5},
+			expectedAction: models.ActionScaleUp,
+		},
 		{
 			name: "maintain due to rising trend blocking scale down",
 			analyzed: &models.AnalyzedMetrics{
@@ -175,3 +187,774 @@ func TestEngine_Decide_CooldownActive(t *testing.T) {
 
 	assert.True(t, result.CooldownActive, "expected CooldownActive to be true")
 }
+
+func TestEngine_Decide_Prediction(t *testing.T) {
+	analyzed := &models.AnalyzedMetrics{
+		ClusterID: "test-cluster",
+		AvgCPU:    60.0,
+		CPUStatus: models.ThresholdNormal,
+		Trend:     models.TrendStable,
+	}
+	state := &models.ClusterState{ActiveServers: 5, TotalServers: 5}
+
+	tests := []struct {
+		name           string
+		confidence     float64
+		expectedAction models.ScalingAction
+	}{
+		{name: "confident forecast scales up proactively", confidence: 0.8, expectedAction: models.ActionScaleUp},
+		{name: "low confidence forecast is ignored", confidence: 0.5, expectedAction: models.ActionMaintain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newTestEngine()
+			prediction := models.NewPrediction("test-cluster", time.Now().Add(15*time.Minute), 90.0, tt.confidence)
+
+			result := engine.Decide(analyzed, prediction, state)
+
+			assert.Equal(t, tt.expectedAction, result.Action)
+			if tt.expectedAction == models.ActionScaleUp {
+				assert.True(t, result.PredictionUsed)
+				assert.Equal(t, tt.confidence, result.Confidence)
+			}
+		})
+	}
+}
+
+func TestEngine_Decide_Memory(t *testing.T) {
+	sustainedPast := time.Now().Add(-60 * time.Second)
+
+	tests := []struct {
+		name           string
+		analyzed       *models.AnalyzedMetrics
+		state          *models.ClusterState
+		expectedAction models.ScalingAction
+		expectedReason string
+	}{
+		{
+			name: "scale up on critical memory with low CPU",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID:      "test-cluster",
+				AvgCPU:         40.0,
+				AvgMemory:      97.0,
+				CPUStatus:      models.ThresholdNormal,
+				MemoryStatus:   models.ThresholdCritical,
+				Trend:          models.TrendStable,
+				SustainedLowAt: &sustainedPast,
+			},
+			state:          &models.ClusterState{ActiveServers: 5, TotalServers: 5},
+			expectedAction: models.ActionScaleUp,
+			expectedReason: "memory_critical",
+		},
+		{
+			name: "scale up on sustained high memory",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID:             "test-cluster",
+				AvgCPU:                50.0,
+				AvgMemory:             88.0,
+				CPUStatus:             models.ThresholdNormal,
+				MemoryStatus:          models.ThresholdWarning,
+				Trend:                 models.TrendStable,
+				SustainedMemoryHighAt: &sustainedPast,
+			},
+			state:          &models.ClusterState{ActiveServers: 5, TotalServers: 5},
+			expectedAction: models.ActionScaleUp,
+			expectedReason: "sustained_high_memory",
+		},
+		{
+			name: "high memory blocks CPU scale down",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID:      "test-cluster",
+				AvgCPU:         20.0,
+				AvgMemory:      88.0,
+				CPUStatus:      models.ThresholdNormal,
+				MemoryStatus:   models.ThresholdWarning,
+				Trend:          models.TrendStable,
+				SustainedLowAt: &sustainedPast,
+			},
+			state:          &models.ClusterState{ActiveServers: 5, TotalServers: 5},
+			expectedAction: models.ActionMaintain,
+		},
+		{
+			name: "scale down blocked when remaining servers would be memory bound",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID:      "test-cluster",
+				AvgCPU:         20.0,
+				AvgMemory:      70.0,
+				CPUStatus:      models.ThresholdNormal,
+				Trend:          models.TrendStable,
+				SustainedLowAt: &sustainedPast,
+			},
+			state:          &models.ClusterState{ActiveServers: 3, TotalServers: 3},
+			expectedAction: models.ActionMaintain,
+		},
+		{
+			name: "scale down names the CPU as the driver",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID:      "test-cluster",
+				AvgCPU:         20.0,
+				AvgMemory:      30.0,
+				CPUStatus:      models.ThresholdNormal,
+				Trend:          models.TrendStable,
+				SustainedLowAt: &sustainedPast,
+			},
+			state:          &models.ClusterState{ActiveServers: 5, TotalServers: 5},
+			expectedAction: models.ActionScaleDown,
+			expectedReason: "sustained_low_cpu",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newTestEngine()
+
+			result := engine.Decide(tt.analyzed, nil, tt.state)
+
+			assert.Equal(t, tt.expectedAction, result.Action)
+			if tt.expectedReason != "" {
+				assert.Equal(t, tt.expectedReason, result.Reason)
+			}
+		})
+	}
+}
+
+func TestEngine_Decide_TargetTracking(t *testing.T) {
+	tests := []struct {
+		name           string
+		signals        []models.ScalingSignal
+		analyzed       *models.AnalyzedMetrics
+		activeServers  int
+		expectedAction models.ScalingAction
+		expectedTarget int
+		expectedReason string
+	}{
+		{
+			name:           "load above target scales up",
+			signals:        []models.ScalingSignal{models.SignalLoad},
+			analyzed:       &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 40, TotalLoad: 650},
+			activeServers:  5,
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 7,
+			expectedReason: "target_tracking_load",
+		},
+		{
+			name:           "scale up is limited by max step",
+			signals:        []models.ScalingSignal{models.SignalLoad},
+			analyzed:       &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 40, TotalLoad: 950},
+			activeServers:  3,
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 6,
+		},
+		{
+			name:           "load below target scales down",
+			signals:        []models.ScalingSignal{models.SignalLoad},
+			analyzed:       &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 40, TotalLoad: 800},
+			activeServers:  10,
+			expectedAction: models.ActionScaleDown,
+			expectedTarget: 8,
+		},
+		{
+			name:           "scale down is limited by max scale-down percent",
+			signals:        []models.ScalingSignal{models.SignalLoad},
+			analyzed:       &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 40, TotalLoad: 300},
+			activeServers:  5,
+			expectedAction: models.ActionScaleDown,
+			expectedTarget: 4,
+		},
+
+		{
+			name:           "exactly on target maintains",
+			signals:        []models.ScalingSignal{models.SignalLoad},
+			analyzed:       &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 40, TotalLoad: 500},
+			activeServers:  5,
+			expectedAction: models.ActionMaintain,
+			expectedTarget: 5,
+		},
+		{
+			name:           "busiest signal wins",
+			signals:        []models.ScalingSignal{models.SignalLoad, models.SignalCPU},
+			analyzed:       &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 84, TotalLoad: 300},
+			activeServers:  5,
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 6,
+			expectedReason: "target_tracking_cpu",
+		},
+		{
+			name:    "cpu statistic drives cpu tracking",
+			signals: []models.ScalingSignal{models.SignalCPU},
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID: "test-cluster", AvgCPU: 50,
+				CPUStatistic: models.CPUStatisticP95, ScalingCPU: 84,
+			},
+			activeServers:  5,
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 6,
+			expectedReason: "target_tracking_cpu",
+		},
+		{
+			name:           "scale down never goes below min servers",
+			signals:        []models.ScalingSignal{models.SignalLoad},
+			analyzed:       &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 5, TotalLoad: 10},
+			activeServers:  3,
+			expectedAction: models.ActionScaleDown,
+			expectedTarget: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := decision.NewEngine(decision.Config{
+				MinServers:          2,
+				MaxServers:          10,
+				MaxScaleStep:        3,
+				TargetCPU:           70.0,
+				ScalingMode:         models.ScalingModeTargetTracking,
+				ScalingSignals:      tt.signals,
+				TargetLoadPerServer: 100,
+			})
+			state := &models.ClusterState{ActiveServers: tt.activeServers, TotalServers: tt.activeServers}
+
+			result := engine.Decide(tt.analyzed, nil, state)
+
+			assert.Equal(t, tt.expectedAction, result.Action)
+			assert.Equal(t, tt.expectedTarget, result.TargetServers)
+			if tt.expectedReason != "" {
+				assert.Equal(t, tt.expectedReason, result.Reason)
+			}
+		})
+	}
+}
+
+func TestEngine_Decide_CustomSignals(t *testing.T) {
+	high, low := 500.0, 200.0
+	queueDepth := models.CustomSignal{Name: "queue_depth", Aggregation: models.CustomAggregationSum, Target: 20}
+	latency := models.CustomSignal{Name: "p99_latency_ms", Target: 200, High: &high, Low: &low}
+	custom := func(queue, latencyMs float64) map[string]models.CustomMetricValue {
+		return map[string]models.CustomMetricValue{
+			"queue_depth":    {Avg: queue / 5, Max: queue / 5, Sum: queue, Count: 5},
+			"p99_latency_ms": {Avg: latencyMs, Max: latencyMs, Sum: latencyMs * 5, Count: 5},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mode           models.ScalingMode
+		signals        []models.CustomSignal
+		analyzed       *models.AnalyzedMetrics
+		expectedAction models.ScalingAction
+		expectedTarget int
+		expectedReason string
+		expectedCheck  string
+	}{
+		{
+			name:           "queue depth per server drives target tracking",
+			mode:           models.ScalingModeTargetTracking,
+			signals:        []models.CustomSignal{queueDepth},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 40, Custom: custom(130, 100)},
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 7,
+			expectedReason: "target_tracking_custom_queue_depth",
+		},
+		{
+			name:           "average latency scales with server count",
+			mode:           models.ScalingModeTargetTracking,
+			signals:        []models.CustomSignal{latency},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 40, Custom: custom(0, 260)},
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 7,
+			expectedReason: "target_tracking_custom_p99_latency_ms",
+		},
+		{
+			name:           "high latency scales up",
+			mode:           models.ScalingModeThreshold,
+			signals:        []models.CustomSignal{latency},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 50, Trend: models.TrendStable, Custom: custom(0, 600)},
+			expectedAction: models.ActionScaleUp,
+			expectedReason: "custom_p99_latency_ms_high",
+		},
+		{
+			name:           "latency above low holds off scale down",
+			mode:           models.ScalingModeThreshold,
+			signals:        []models.CustomSignal{latency},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 20, AvgMemory: 30, Trend: models.TrendStable, Custom: custom(0, 300)},
+			expectedAction: models.ActionMaintain,
+			expectedTarget: 5,
+			expectedReason: "within_normal_parameters",
+		},
+		{
+			name:           "latency at low allows scale down",
+			mode:           models.ScalingModeThreshold,
+			signals:        []models.CustomSignal{latency},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 20, AvgMemory: 30, Trend: models.TrendStable, Custom: custom(0, 150)},
+			expectedAction: models.ActionScaleDown,
+			expectedTarget: 4,
+		},
+		{
+			name:           "unreported metric holds off scale down",
+			mode:           models.ScalingModeThreshold,
+			signals:        []models.CustomSignal{latency},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 20, AvgMemory: 30, Trend: models.TrendStable},
+			expectedAction: models.ActionMaintain,
+			expectedTarget: 5,
+			expectedCheck:  "custom_p99_latency_ms_missing",
+		},
+		{
+			name:           "unreported metric without a low threshold is ignored",
+			mode:           models.ScalingModeThreshold,
+			signals:        []models.CustomSignal{queueDepth},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 20, AvgMemory: 30, Trend: models.TrendStable},
+			expectedAction: models.ActionScaleDown,
+			expectedTarget: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := decision.NewEngine(decision.Config{
+				MinServers:    2,
+				MaxServers:    10,
+				MaxScaleStep:  3,
+				TargetCPU:     70.0,
+				ScalingMode:   tt.mode,
+				CustomSignals: tt.signals,
+			})
+			tt.analyzed.ClusterID = "test-cluster"
+			state := &models.ClusterState{ActiveServers: 5, TotalServers: 5}
+
+			result := engine.Decide(tt.analyzed, nil, state)
+
+			assert.Equal(t, tt.expectedAction, result.Action)
+			if tt.expectedTarget != 0 {
+				assert.Equal(t, tt.expectedTarget, result.TargetServers)
+			}
+			if tt.expectedReason != "" {
+				assert.Equal(t, tt.expectedReason, result.Reason)
+			}
+			if _, reported := tt.analyzed.Custom["p99_latency_ms"]; reported {
+				assert.Contains(t, result.Trace.Inputs.Custom, tt.signals[0].Name)
+			}
+			if tt.expectedCheck != "" {
+				var checks []string
+				for _, check := range result.Trace.Checks {
+					if check.Matched {
+						checks = append(checks, check.Name)
+					}
+				}
+				assert.Contains(t, checks, tt.expectedCheck)
+			}
+		})
+	}
+}
+
+func TestEngine_Decide_Policy(t *testing.T) {
+	sustainedPast := time.Now().Add(-60 * time.Second)
+
+	policy := &models.ScalingPolicy{
+		Rules: []models.PolicyRule{
+			{
+				Name: "protect-busy-memory",
+				Conditions: []models.PolicyCondition{
+					{Field: models.PolicyFieldAvgMemory, Operator: models.OperatorGTE, Value: 60.0},
+				},
+				Action: models.PolicyAction{Type: models.PolicyActionBlockScaleDown},
+			},
+			{
+				Name: "burst-on-spike",
+				Conditions: []models.PolicyCondition{
+					{Field: models.PolicyFieldHasSpike, Operator: models.OperatorEQ, Value: true},
+					{Field: models.PolicyFieldAvgCPU, Operator: models.OperatorGT, Value: 60.0},
+				},
+				Action: models.PolicyAction{Type: models.PolicyActionAddPercent, Value: 40},
+			},
+			{
+				Name: "forecast-peak",
+				Conditions: []models.PolicyCondition{
+					{Field: models.PolicyFieldPredictedCPU, Operator: models.OperatorGTE, Value: 85.0},
+				},
+				Action: models.PolicyAction{Type: models.PolicyActionAdd, Value: 1},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		analyzed       *models.AnalyzedMetrics
+		prediction     *models.Prediction
+		expectedAction models.ScalingAction
+		expectedTarget int
+		expectedRule   string
+	}{
+		{
+			name: "percentage rule fires on spike",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID: "test-cluster", AvgCPU: 70, AvgMemory: 50, HasSpike: true,
+			},
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 7,
+			expectedRule:   "burst-on-spike",
+		},
+		{
+			name: "forecast rule uses the prediction",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID: "test-cluster", AvgCPU: 50, AvgMemory: 50, Trend: models.TrendStable,
+			},
+			prediction:     models.NewPrediction("test-cluster", time.Now().Add(15*time.Minute), 90.0, 0.5),
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 6,
+			expectedRule:   "forecast-peak",
+		},
+		{
+			name: "block rule vetoes built-in scale down",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID: "test-cluster", AvgCPU: 20, AvgMemory: 65, Trend: models.TrendStable,
+				SustainedLowAt: &sustainedPast,
+			},
+			expectedAction: models.ActionMaintain,
+			expectedTarget: 5,
+			expectedRule:   "protect-busy-memory",
+		},
+		{
+			name: "built-in logic runs when no rule matches",
+			analyzed: &models.AnalyzedMetrics{
+				ClusterID: "test-cluster", AvgCPU: 20, AvgMemory: 30, Trend: models.TrendStable,
+				SustainedLowAt: &sustainedPast,
+			},
+			expectedAction: models.ActionScaleDown,
+			expectedTarget: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := decision.NewEngine(decision.Config{
+				MinServers:            2,
+				MaxServers:            10,
+				MaxScaleStep:          3,
+				SustainedLowDuration:  30 * time.Second,
+				SustainedHighDuration: 30 * time.Second,
+				Policy:                policy,
+			})
+			state := &models.ClusterState{ActiveServers: 5, TotalServers: 5}
+
+			result := engine.Decide(tt.analyzed, tt.prediction, state)
+
+			assert.Equal(t, tt.expectedAction, result.Action)
+			assert.Equal(t, tt.expectedTarget, result.TargetServers)
+			assert.Equal(t, tt.expectedRule, result.Rule)
+		})
+	}
+}
+
+func TestEngine_Decide_Override(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	normal := &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 55, Trend: models.TrendStable}
+
+	t.Run("raised minimum scales up within the step limit", func(t *testing.T) {
+		engine := newTestEngine()
+		engine.ApplyOverride(decision.Override{ID: "s1", Name: "business-hours", MinServers: intPtr(10), StartedAt: time.Now()})
+
+		result := engine.Decide(normal, nil, &models.ClusterState{ActiveServers: 4, TotalServers: 4})
+
+		assert.Equal(t, models.ActionScaleUp, result.Action)
+		assert.Equal(t, 7, result.TargetServers)
+		assert.Equal(t, "schedule:business-hours", result.Reason)
+	})
+
+	t.Run("provisioning servers count towards the minimum", func(t *testing.T) {
+		engine := newTestEngine()
+		engine.ApplyOverride(decision.Override{ID: "s1", Name: "business-hours", MinServers: intPtr(6), StartedAt: time.Now()})
+
+		result := engine.Decide(normal, nil, &models.ClusterState{ActiveServers: 4, TotalServers: 6, ProvisioningCnt: 2})
+
+		assert.Equal(t, models.ActionMaintain, result.Action)
+	})
+
+	t.Run("desired count pins the cluster size", func(t *testing.T) {
+		engine := newTestEngine()
+		engine.ApplyOverride(decision.Override{ID: "s1", Name: "batch", DesiredServers: intPtr(3), StartedAt: time.Now()})
+
+		result := engine.Decide(normal, nil, &models.ClusterState{ActiveServers: 5, TotalServers: 5})
+
+		assert.Equal(t, models.ActionScaleDown, result.Action)
+		assert.Equal(t, 3, result.TargetServers)
+	})
+
+	t.Run("latest override wins and clearing restores limits", func(t *testing.T) {
+		engine := newTestEngine()
+		now := time.Now()
+		engine.ApplyOverride(decision.Override{ID: "s1", Name: "early", MinServers: intPtr(4), StartedAt: now.Add(-time.Hour)})
+		engine.ApplyOverride(decision.Override{ID: "s2", Name: "late", MaxServers: intPtr(3), StartedAt: now})
+
+		active, ok := engine.ActiveOverride()
+		assert.True(t, ok)
+		assert.Equal(t, "late", active.Name)
+
+		engine.ClearOverride("s2")
+		engine.ClearOverride("s1")
+		_, ok = engine.ActiveOverride()
+		assert.False(t, ok)
+
+		result := engine.Decide(normal, nil, &models.ClusterState{ActiveServers: 5, TotalServers: 5})
+		assert.Equal(t, models.ActionMaintain, result.Action)
+	})
+}
+
+func TestEngine_Decide_ProportionalScaleDown(t *testing.T) {
+	sustainedPast := time.Now().Add(-60 * time.Second)
+
+	tests := []struct {
+		name           string
+		config         decision.Config
+		analyzed       *models.AnalyzedMetrics
+		servers        int
+		expectedTarget int
+	}{
+		{
+			name:           "conservative mode removes one server",
+			config:         decision.Config{ScaleDownMode: decision.ScaleDownConservative},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 10, AvgMemory: 10},
+			servers:        60,
+			expectedTarget: 59,
+		},
+		{
+			name:           "proportional mode is bounded by the max percentage",
+			config:         decision.Config{ScaleDownMode: decision.ScaleDownProportional, MaxScaleDownStep: 20},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 10, AvgMemory: 10},
+			servers:        60,
+			expectedTarget: 45,
+		},
+		{
+			name:           "proportional mode is bounded by the max step",
+			config:         decision.Config{ScaleDownMode: decision.ScaleDownProportional, MaxScaleDownStep: 5},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 10, AvgMemory: 10},
+			servers:        60,
+			expectedTarget: 55,
+		},
+		{
+			name: "safety margin keeps projected CPU below the high threshold",
+			config: decision.Config{
+				ScaleDownMode: decision.ScaleDownProportional, MaxScaleDownStep: 10,
+				MaxScaleDownPercent: 50, ScaleDownSafetyMargin: 40, TargetCPU: 90,
+			},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 25, AvgMemory: 20},
+			servers:        10,
+			expectedTarget: 7,
+		},
+		{
+			name: "zero safety margin only keeps projected CPU below the high threshold",
+			config: decision.Config{
+				ScaleDownMode: decision.ScaleDownProportional, MaxScaleDownStep: 10,
+				MaxScaleDownPercent: 50, TargetCPU: 90, CPULowThreshold: 40,
+			},
+			analyzed:       &models.AnalyzedMetrics{AvgCPU: 35, AvgMemory: 20},
+			servers:        10,
+			expectedTarget: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.config
+			cfg.MinServers = 2
+			cfg.MaxServers = 100
+			cfg.SustainedLowDuration = 30 * time.Second
+			engine := decision.NewEngine(cfg)
+
+			tt.analyzed.ClusterID = "test-cluster"
+			tt.analyzed.Trend = models.TrendStable
+			tt.analyzed.SustainedLowAt = &sustainedPast
+			state := &models.ClusterState{ActiveServers: tt.servers, TotalServers: tt.servers}
+
+			result := engine.Decide(tt.analyzed, nil, state)
+
+			assert.Equal(t, models.ActionScaleDown, result.Action)
+			assert.Equal(t, tt.expectedTarget, result.TargetServers)
+		})
+	}
+}
+
+func TestEngine_Decide_Provisioning(t *testing.T) {
+	sustainedPast := time.Now().Add(-60 * time.Second)
+	emergency := &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 97, CPUStatus: models.ThresholdCritical}
+	high := &models.AnalyzedMetrics{
+		ClusterID: "test-cluster", AvgCPU: 90, CPUStatus: models.ThresholdWarning,
+		Trend: models.TrendStable, SustainedHighAt: &sustainedPast,
+	}
+
+	tests := []struct {
+		name           string
+		analyzed       *models.AnalyzedMetrics
+		state          *models.ClusterState
+		expectedAction models.ScalingAction
+		expectedTarget int
+		expectedReason string
+	}{
+		{
+			name:           "emergency only adds what is not already provisioning",
+			analyzed:       emergency,
+			state:          &models.ClusterState{ActiveServers: 4, ProvisioningCnt: 1, TotalServers: 5},
+			expectedAction: models.ActionScaleUp,
+			expectedTarget: 7,
+			expectedReason: "emergency_cpu_critical",
+		},
+		{
+			name:           "emergency waits for a full step in flight",
+			analyzed:       emergency,
+			state:          &models.ClusterState{ActiveServers: 4, ProvisioningCnt: 3, TotalServers: 7},
+			expectedAction: models.ActionMaintain,
+			expectedTarget: 7,
+			expectedReason: "provisioning_in_flight",
+		},
+		{
+			name:           "sustained high CPU covered by provisioning servers",
+			analyzed:       high,
+			state:          &models.ClusterState{ActiveServers: 4, ProvisioningCnt: 2, TotalServers: 6},
+			expectedAction: models.ActionMaintain,
+			expectedTarget: 6,
+			expectedReason: "provisioning_in_flight",
+		},
+		{
+			name:           "draining servers don't count as capacity",
+			analyzed:       &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 55, Trend: models.TrendStable},
+			state:          &models.ClusterState{ActiveServers: 5, DrainingCount: 2, TotalServers: 7},
+			expectedAction: models.ActionMaintain,
+			expectedTarget: 5,
+			expectedReason: "within_normal_parameters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newTestEngine()
+
+			result := engine.Decide(tt.analyzed, nil, tt.state)
+
+			assert.Equal(t, tt.expectedAction, result.Action)
+			assert.Equal(t, tt.expectedTarget, result.TargetServers)
+			assert.Equal(t, tt.expectedReason, result.Reason)
+			assert.Equal(t, tt.state.ProvisioningCnt, result.ProvisioningServers)
+			assert.Equal(t, tt.state.DrainingCount, result.DrainingServers)
+			assert.Equal(t, tt.state.ProvisioningCnt, result.Trace.Inputs.ProvisioningServers)
+		})
+	}
+
+	t.Run("target tracking counts provisioning servers towards the target", func(t *testing.T) {
+		engine := decision.NewEngine(decision.Config{ScalingMode: models.ScalingModeTargetTracking, TargetCPU: 70})
+		analyzed := &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 90}
+
+		covered := engine.Decide(analyzed, nil, &models.ClusterState{ActiveServers: 4, ProvisioningCnt: 2, TotalServers: 6})
+		assert.Equal(t, models.ActionMaintain, covered.Action)
+		assert.Equal(t, "provisioning_in_flight", covered.Reason)
+
+		short := engine.Decide(analyzed, nil, &models.ClusterState{ActiveServers: 4, ProvisioningCnt: 1, TotalServers: 5})
+		assert.Equal(t, models.ActionScaleUp, short.Action)
+		assert.Equal(t, 1, short.ServerDelta())
+	})
+}
+
+func TestEngine_Decide_Trace(t *testing.T) {
+	t.Run("maintain lists every check that ran", func(t *testing.T) {
+		engine := newTestEngine()
+		analyzed := &models.AnalyzedMetrics{
+			ClusterID: "test-cluster", AvgCPU: 55, AvgMemory: 50,
+			CPUStatus: models.ThresholdNormal, Trend: models.TrendStable,
+		}
+
+		result := engine.Decide(analyzed, nil, &models.ClusterState{ActiveServers: 5, TotalServers: 5})
+
+		assert.Equal(t, models.ActionMaintain, result.Action)
+		assert.NotNil(t, result.Trace)
+		assert.Equal(t, 55.0, result.Trace.Inputs.AvgCPU)
+		assert.Equal(t, 10, result.Trace.Limits.MaxServers)
+		assert.False(t, result.Trace.Cooldown.ScaleUpActive)
+
+		names := make([]string, len(result.Trace.Checks))
+		for i, check := range result.Trace.Checks {
+			names[i] = check.Name
+			assert.False(t, check.Matched, check.Name)
+		}
+		assert.Contains(t, names, "emergency_cpu")
+		assert.Contains(t, names, "sustained_high_cpu")
+		assert.Contains(t, names, "low_cpu_stable_or_falling")
+	})
+
+	t.Run("cooldown and clamping are recorded", func(t *testing.T) {
+		engine := newTestEngine()
+		analyzed := &models.AnalyzedMetrics{
+			ClusterID: "test-cluster", AvgCPU: 92, CPUStatus: models.ThresholdCritical,
+		}
+
+		result := engine.Decide(analyzed, nil, &models.ClusterState{ActiveServers: 9, TotalServers: 9})
+		assert.Equal(t, 10, result.TargetServers)
+		assert.Contains(t, result.Trace.Clamps, models.DecisionClamp{Limit: "max_servers", From: 11, To: 10})
+
+		engine.RecordScaleUp("test-cluster")
+		result = engine.Decide(analyzed, nil, &models.ClusterState{ActiveServers: 5, TotalServers: 5})
+		assert.True(t, result.CooldownActive)
+		assert.True(t, result.Trace.Cooldown.ScaleUpActive)
+		assert.Greater(t, result.Trace.Cooldown.ScaleUpRemainingSeconds, 0.0)
+	})
+}
+
+func TestFlapDetector(t *testing.T) {
+	start := time.Now()
+	detector := decision.NewFlapDetector(decision.FlapConfig{
+		Enabled: true, Window: 10 * time.Minute, Reversals: 3, StableFor: 5 * time.Minute,
+	})
+
+	moves := []models.ScalingAction{
+		models.ActionScaleUp, models.ActionScaleDown, models.ActionScaleUp, models.ActionScaleDown,
+	}
+	for i, action := range moves {
+		detector.Record("test-cluster", action, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	state := detector.Evaluate("test-cluster", start.Add(4*time.Minute))
+	assert.True(t, state.Active)
+	assert.Equal(t, 3, state.Reversals)
+	assert.Equal(t, start.Add(8*time.Minute), *state.RelaxesAt)
+
+	// Moves in one direction are not flapping
+	detector.Record("steady-cluster", models.ActionScaleUp, start)
+	detector.Record("steady-cluster", models.ActionScaleUp, start.Add(time.Minute))
+	assert.False(t, detector.Evaluate("steady-cluster", start.Add(2*time.Minute)).Active)
+
+	// Damping relaxes once no reversal happened for StableFor
+	state = detector.Evaluate("test-cluster", start.Add(9*time.Minute))
+	assert.False(t, state.Active)
+	assert.Equal(t, 0, state.Reversals)
+}
+
+func TestEngine_Decide_FlapDamping(t *testing.T) {
+	engine := decision.NewEngine(decision.Config{
+		CooldownPeriod:          time.Nanosecond,
+		ScaleDownCooldownPeriod: time.Minute,
+		MinServers:              2,
+		MaxServers:              10,
+		CPULowThreshold:         30.0,
+		Flap:                    decision.FlapConfig{Enabled: true, Reversals: 3, CooldownMultiplier: 3, HysteresisMargin: 10},
+	})
+	analyzed := &models.AnalyzedMetrics{ClusterID: "test-cluster", AvgCPU: 25, AvgMemory: 30, Trend: models.TrendStable}
+	state := &models.ClusterState{ActiveServers: 5, TotalServers: 5}
+
+	assert.False(t, engine.Damping("test-cluster").Active)
+	assert.Equal(t, models.ActionScaleDown, engine.Decide(analyzed, nil, state).Action)
+
+	for _, action := range []models.ScalingAction{
+		models.ActionScaleUp, models.ActionScaleDown, models.ActionScaleUp, models.ActionScaleDown,
+	} {
+		engine.RecordScalingAction("test-cluster", action)
+	}
+
+	damping := engine.Damping("test-cluster")
+	assert.True(t, damping.Active)
+	assert.Equal(t, 180.0, damping.ScaleDownCooldownSeconds)
+	assert.Equal(t, 20.0, damping.CPULowThreshold)
+
+	// 25% CPU no longer counts as low while the band is widened
+	result := engine.Decide(analyzed, nil, state)
+	assert.Equal(t, models.ActionMaintain, result.Action)
+	assert.True(t, result.Trace.Damping.Active)
+	assert.Greater(t, result.Trace.Cooldown.ScaleDownRemainingSeconds, 60.0)
+}
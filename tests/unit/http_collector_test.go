@@ -0,0 +1,36 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/collector"
+)
+
+func TestHTTPCollector_DropsInvalidCustomMetricNames(t *testing.T) {
+	longName := strings.Repeat("q", 101)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"timestamp": "2026-01-01T00:00:00Z",
+			"servers": [{"server_id": "s1", "cpu_usage": 40, "memory_usage": 50,
+				"custom": {"queue_depth": 12, "Queue-Depth": 3, "` + longName + `": 1}}],
+			"custom": {"p99_latency_ms": 180, "1st_byte": 20}
+		}`))
+	}))
+	defer server.Close()
+
+	coll := collector.NewHTTPCollector(collector.HTTPCollectorConfig{Endpoint: server.URL})
+	metrics, err := coll.Collect(context.Background(), "test-cluster")
+	require.NoError(t, err)
+	require.Len(t, metrics.Servers, 1)
+
+	assert.Equal(t, map[string]float64{"queue_depth": 12}, metrics.Servers[0].Custom)
+	assert.Equal(t, map[string]float64{"p99_latency_ms": 180}, metrics.Custom)
+}
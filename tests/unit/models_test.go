@@ -1,6 +1,8 @@
 package unit
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -49,6 +51,56 @@ func TestClusterMetrics_CalculateAggregates(t *testing.T) {
 	}
 }
 
+func TestClusterMetrics_CalculateAggregates_Percentiles(t *testing.T) {
+	metrics := &models.ClusterMetrics{ClusterID: "test-cluster"}
+	for i := 1; i <= 10; i++ {
+		metrics.Servers = append(metrics.Servers, models.ServerMetric{
+			ServerID: fmt.Sprintf("s%d", i),
+			CPUUsage: float64(i * 10),
+		})
+	}
+
+	agg := metrics.CalculateAggregates()
+
+	assert.Equal(t, 55.0, agg.AvgCPU)
+	assert.InDelta(t, 55.0, agg.P50, 0.001)
+	assert.InDelta(t, 91.0, agg.P90, 0.001)
+	assert.InDelta(t, 95.5, agg.P95, 0.001)
+	assert.InDelta(t, 99.1, agg.P99, 0.001)
+}
+
+func TestClusterMetrics_CalculateAggregates_Custom(t *testing.T) {
+	metrics := &models.ClusterMetrics{
+		ClusterID: "test-cluster",
+		Servers: []models.ServerMetric{
+			{ServerID: "s1", CPUUsage: 40, Custom: map[string]float64{"queue_depth": 30, "p99_latency_ms": 120}},
+			{ServerID: "s2", CPUUsage: 60, Custom: map[string]float64{"queue_depth": 50}},
+		},
+		Custom: map[string]float64{"active_connections": 900},
+	}
+
+	agg := metrics.CalculateAggregates()
+
+	assert.Equal(t, models.CustomMetricValue{Avg: 40, Max: 50, Sum: 80, Count: 2}, agg.Custom["queue_depth"])
+	assert.Equal(t, models.CustomMetricValue{Avg: 120, Max: 120, Sum: 120, Count: 1}, agg.Custom["p99_latency_ms"])
+	assert.Equal(t, 900.0, agg.Custom["active_connections"].Of(models.CustomAggregationSum))
+	assert.Equal(t, 50.0, agg.Custom["queue_depth"].Of(models.CustomAggregationMax))
+
+	empty := (&models.ClusterMetrics{Custom: map[string]float64{"queue_depth": 7}}).CalculateAggregates()
+	assert.Equal(t, 7.0, empty.Custom["queue_depth"].Avg, "cluster-level metrics survive a cycle without servers")
+}
+
+func TestCPUStatistic_Of(t *testing.T) {
+	percentiles := models.CPUPercentiles{P50: 40, P90: 70, P95: 85, P99: 95}
+
+	assert.Equal(t, 45.0, models.CPUStatisticAvg.Of(45, 98, percentiles))
+	assert.Equal(t, 98.0, models.CPUStatisticMax.Of(45, 98, percentiles))
+	assert.Equal(t, 70.0, models.CPUStatisticP90.Of(45, 98, percentiles))
+	assert.Equal(t, 85.0, models.CPUStatisticP95.Of(45, 98, percentiles))
+	assert.Equal(t, 45.0, models.CPUStatistic("").Of(45, 98, percentiles))
+	assert.False(t, models.CPUStatistic("p75").Valid())
+}
+
 func TestClusterState_CanScaleUp(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -145,6 +197,30 @@ func TestScalingDecision_ShouldExecute(t *testing.T) {
 	}
 }
 
+func TestNewDecisionRecord(t *testing.T) {
+	decision := models.ScalingDecision{
+		ClusterID:      "cluster-1",
+		Action:         models.ActionScaleUp,
+		CurrentServers: 3,
+		TargetServers:  5,
+		Reason:         "cpu_critical",
+		Trace:          &models.DecisionTrace{},
+	}
+
+	record := models.NewDecisionRecord(decision, models.OutcomeExecuted, nil)
+	assert.Equal(t, models.OutcomeExecuted, record.Outcome)
+	assert.Empty(t, record.Error)
+	assert.Equal(t, decision, record.ScalingDecision)
+
+	record = models.NewDecisionRecord(decision, models.OutcomeFailed, errors.New("provider unavailable"))
+	assert.Equal(t, models.OutcomeFailed, record.Outcome)
+	assert.Equal(t, "provider unavailable", record.Error)
+
+	// The record is a copy, later changes to the decision don't leak into history
+	decision.TargetServers = 4
+	assert.Equal(t, 5, record.TargetServers)
+}
+
 func TestAnalyzedMetrics_IsCritical(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -185,3 +261,271 @@ func TestAnalyzedMetrics_IsWarning(t *testing.T) {
 
 	assert.True(t, analyzed.IsWarning(), "expected IsWarning to be true")
 }
+
+func TestClusterConfig_Validate(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	stringPtr := func(v string) *string { return &v }
+
+	tests := []struct {
+		name      string
+		config    models.ClusterConfig
+		expectErr bool
+	}{
+		{
+			name:   "empty config",
+			config: models.ClusterConfig{},
+		},
+		{
+			name: "target tracking on load",
+			config: models.ClusterConfig{
+				ScalingMode:         models.ScalingModeTargetTracking,
+				ScalingSignals:      []models.ScalingSignal{models.SignalCPU, models.SignalLoad},
+				TargetLoadPerServer: 100,
+			},
+		},
+		{
+			name: "custom signals",
+			config: models.ClusterConfig{CustomSignals: []models.CustomSignal{
+				{Name: "queue_depth", Aggregation: models.CustomAggregationSum, Target: 20},
+				{Name: "p99_latency_ms", High: floatPtr(500), Low: floatPtr(200)},
+			}},
+		},
+		{
+			name: "custom signal without target or thresholds",
+			config: models.ClusterConfig{CustomSignals: []models.CustomSignal{
+				{Name: "queue_depth"},
+			}},
+			expectErr: true,
+		},
+		{
+			name: "custom signal with invalid name",
+			config: models.ClusterConfig{CustomSignals: []models.CustomSignal{
+				{Name: "Queue-Depth", Target: 20},
+			}},
+			expectErr: true,
+		},
+		{
+			name: "repeated custom signal",
+			config: models.ClusterConfig{CustomSignals: []models.CustomSignal{
+				{Name: "queue_depth", Target: 20},
+				{Name: "queue_depth", High: floatPtr(100)},
+			}},
+			expectErr: true,
+		},
+		{
+			name: "custom signal high below low",
+			config: models.ClusterConfig{CustomSignals: []models.CustomSignal{
+				{Name: "p99_latency_ms", High: floatPtr(100), Low: floatPtr(200)},
+			}},
+			expectErr: true,
+		},
+		{
+			name:   "p95 cpu statistic",
+			config: models.ClusterConfig{CPUStatistic: stringPtr("p95"), PercentileWindowSeconds: intPtr(300)},
+		},
+		{
+			name:      "unknown cpu statistic",
+			config:    models.ClusterConfig{CPUStatistic: stringPtr("median")},
+			expectErr: true,
+		},
+		{
+			name: "load signal without a target",
+			config: models.ClusterConfig{
+				ScalingMode:    models.ScalingModeTargetTracking,
+				ScalingSignals: []models.ScalingSignal{models.SignalLoad},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "unknown mode",
+			config:    models.ClusterConfig{ScalingMode: "guesswork"},
+			expectErr: true,
+		},
+		{
+			name:      "unknown signal",
+			config:    models.ClusterConfig{ScalingSignals: []models.ScalingSignal{"disk"}},
+			expectErr: true,
+		},
+		{
+			name: "threshold and cooldown overrides",
+			config: models.ClusterConfig{
+				CPUHighThreshold: floatPtr(85), CPULowThreshold: floatPtr(25),
+				CooldownSeconds: intPtr(120), ScaleDownMode: stringPtr("proportional"),
+				CollectIntervalSeconds: intPtr(15),
+			},
+		},
+		{
+			name:      "inverted cpu thresholds",
+			config:    models.ClusterConfig{CPUHighThreshold: floatPtr(30), CPULowThreshold: floatPtr(40)},
+			expectErr: true,
+		},
+		{
+			name:      "threshold above 100",
+			config:    models.ClusterConfig{MemoryHighThreshold: floatPtr(120)},
+			expectErr: true,
+		},
+		{
+			name:      "zero cooldown",
+			config:    models.ClusterConfig{ScaleDownCooldownSeconds: intPtr(0)},
+			expectErr: true,
+		},
+		{
+			name:      "collect interval too short",
+			config:    models.ClusterConfig{CollectIntervalSeconds: intPtr(1)},
+			expectErr: true,
+		},
+		{
+			name:      "unknown scale-down mode",
+			config:    models.ClusterConfig{ScaleDownMode: stringPtr("aggressive")},
+			expectErr: true,
+		},
+		{
+			name:   "observe mode",
+			config: models.ClusterConfig{Mode: models.ClusterModeObserve},
+		},
+		{
+			name:      "unknown mode",
+			config:    models.ClusterConfig{Mode: "shadow"},
+			expectErr: true,
+		},
+		{
+			name:      "collector endpoint without scheme",
+			config:    models.ClusterConfig{CollectorEndpoint: "metrics:9000/metrics"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCluster_IsObserveOnly(t *testing.T) {
+	cluster := &models.Cluster{}
+	assert.False(t, cluster.IsObserveOnly())
+
+	cluster.Config = &models.ClusterConfig{Mode: models.ClusterModeManaged}
+	assert.False(t, cluster.IsObserveOnly())
+
+	cluster.Config.Mode = models.ClusterModeObserve
+	assert.True(t, cluster.IsObserveOnly())
+}
+
+func TestCluster_MetricsEndpoint(t *testing.T) {
+	cluster := &models.Cluster{ID: "abc"}
+	assert.Equal(t, "http://localhost:9000/metrics/abc", cluster.MetricsEndpoint("http://localhost:9000/metrics/"))
+
+	cluster.Config = &models.ClusterConfig{CollectorEndpoint: "https://metrics.internal/abc"}
+	assert.Equal(t, "https://metrics.internal/abc", cluster.MetricsEndpoint("http://localhost:9000/metrics"))
+}
+
+func TestScalingPolicy_Validate(t *testing.T) {
+	validRule := models.PolicyRule{
+		Name: "high-cpu",
+		Conditions: []models.PolicyCondition{
+			{Field: models.PolicyFieldAvgCPU, Operator: models.OperatorGT, Value: 80.0},
+			{Field: models.PolicyFieldTrend, Operator: models.OperatorEQ, Value: "rising"},
+		},
+		Action: models.PolicyAction{Type: models.PolicyActionAdd, Value: 2},
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(r *models.PolicyRule)
+		expectErr bool
+	}{
+		{name: "valid rule", mutate: func(r *models.PolicyRule) {}},
+		{name: "unknown field", mutate: func(r *models.PolicyRule) { r.Conditions[0].Field = "disk_io" }, expectErr: true},
+		{name: "unknown operator", mutate: func(r *models.PolicyRule) { r.Conditions[0].Operator = "between" }, expectErr: true},
+		{name: "string value on numeric field", mutate: func(r *models.PolicyRule) { r.Conditions[0].Value = "high" }, expectErr: true},
+		{name: "ordering on trend", mutate: func(r *models.PolicyRule) { r.Conditions[1].Operator = models.OperatorGT }, expectErr: true},
+		{name: "unknown action", mutate: func(r *models.PolicyRule) { r.Action.Type = "reboot" }, expectErr: true},
+		{name: "fractional set target", mutate: func(r *models.PolicyRule) {
+			r.Action = models.PolicyAction{Type: models.PolicyActionSetTarget, Value: 2.5}
+		}, expectErr: true},
+		{name: "fractional add", mutate: func(r *models.PolicyRule) { r.Action.Value = 1.5 }, expectErr: true},
+		{name: "add below one server", mutate: func(r *models.PolicyRule) { r.Action.Value = 0.5 }, expectErr: true},
+		{name: "fractional add percent", mutate: func(r *models.PolicyRule) {
+			r.Action = models.PolicyAction{Type: models.PolicyActionAddPercent, Value: 12.5}
+		}},
+		{name: "missing name", mutate: func(r *models.PolicyRule) { r.Name = "" }, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := validRule
+			rule.Conditions = append([]models.PolicyCondition(nil), validRule.Conditions...)
+			tt.mutate(&rule)
+
+			policy := models.ScalingPolicy{Rules: []models.PolicyRule{rule}}
+			err := policy.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApprovalPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    models.ApprovalPolicy
+		expectErr bool
+	}{
+		{name: "delta limit", policy: models.ApprovalPolicy{MaxDelta: 3}},
+		{name: "cost limit", policy: models.ApprovalPolicy{ServerHourlyCost: 1.2, MaxHourlyCost: 5, OnTimeout: models.ApprovalTimeoutApprove}},
+		{name: "no limit", policy: models.ApprovalPolicy{TimeoutSeconds: 60}, expectErr: true},
+		{name: "cost limit without server cost", policy: models.ApprovalPolicy{MaxHourlyCost: 5}, expectErr: true},
+		{name: "negative delta", policy: models.ApprovalPolicy{MaxDelta: -1}, expectErr: true},
+		{name: "unknown timeout action", policy: models.ApprovalPolicy{MaxDelta: 3, OnTimeout: "escalate"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApprovalPolicy_Requires(t *testing.T) {
+	userID := 7
+	policy := models.ApprovalPolicy{MaxDelta: 2, ServerHourlyCost: 1.5, MaxHourlyCost: 4}
+
+	tests := []struct {
+		name     string
+		decision models.ScalingDecision
+		expected bool
+	}{
+		{"small scale-up", models.ScalingDecision{Action: models.ActionScaleUp, CurrentServers: 4, TargetServers: 6}, false},
+		{"delta above limit", models.ScalingDecision{Action: models.ActionScaleUp, CurrentServers: 4, TargetServers: 7}, true},
+		{"emergency", models.ScalingDecision{Action: models.ActionScaleUp, CurrentServers: 4, TargetServers: 7, IsEmergency: true}, false},
+		{"operator initiated", models.ScalingDecision{Action: models.ActionScaleUp, CurrentServers: 4, TargetServers: 7, InitiatedBy: &userID}, false},
+		{"scale-down", models.ScalingDecision{Action: models.ActionScaleDown, CurrentServers: 9, TargetServers: 4}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Requires(&tt.decision))
+		})
+	}
+
+	// Cost alone: 3 servers at 1.5 per hour exceed 4 per hour
+	costOnly := models.ApprovalPolicy{ServerHourlyCost: 1.5, MaxHourlyCost: 4}
+	assert.True(t, costOnly.Requires(&models.ScalingDecision{Action: models.ActionScaleUp, CurrentServers: 4, TargetServers: 7}))
+	assert.False(t, costOnly.Requires(&models.ScalingDecision{Action: models.ActionScaleUp, CurrentServers: 4, TargetServers: 6}))
+}
@@ -0,0 +1,323 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/analyzer"
+	"github.com/OldStager01/cloud-autoscaler/internal/predictor"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+type fakePatternSource struct {
+	records map[[2]int]*models.PatternRecord
+}
+
+func (f *fakePatternSource) GetBucket(ctx context.Context, clusterID string, dayOfWeek, hourOfDay int) (*models.PatternRecord, error) {
+	return f.records[[2]int{dayOfWeek, hourOfDay}], nil
+}
+
+func buildHistory(start time.Time, step time.Duration, values ...float64) []analyzer.Snapshot {
+	history := make([]analyzer.Snapshot, len(values))
+	for i, v := range values {
+		history[i] = analyzer.Snapshot{
+			Timestamp: start.Add(time.Duration(i) * step),
+			AvgCPU:    v,
+		}
+	}
+	return history
+}
+
+func TestPredictor_New(t *testing.T) {
+	p, err := predictor.New(predictor.Config{Types: []string{predictor.TypeLinearTrend}})
+	require.NoError(t, err)
+	assert.Equal(t, predictor.TypeLinearTrend, p.Name())
+
+	_, err = predictor.New(predictor.Config{Types: []string{"crystal_ball"}})
+	assert.ErrorIs(t, err, predictor.ErrUnknownType)
+
+	_, err = predictor.New(predictor.Config{Types: []string{predictor.TypePatternMatching}})
+	assert.Error(t, err, "pattern matching needs a pattern source")
+
+	p, err = predictor.New(predictor.Config{Types: []string{predictor.TypeLinearTrend, predictor.TypeHoltWinters}})
+	require.NoError(t, err)
+	assert.Equal(t, predictor.TypeEnsemble, p.Name())
+
+	_, err = predictor.New(predictor.Config{})
+	assert.ErrorIs(t, err, predictor.ErrUnknownType)
+}
+
+func TestPatternMatching_Predict(t *testing.T) {
+	// Sunday 23:50 UTC + 15m lands in Monday 00:00
+	now := time.Date(2026, 10, 11, 23, 50, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		record        *models.PatternRecord
+		expectErr     error
+		expectCPU     float64
+		minConfidence float64
+		maxConfidence float64
+	}{
+		{
+			name:      "no pattern for bucket",
+			expectErr: predictor.ErrInsufficientData,
+		},
+		{
+			name:          "stable pattern with four weeks is trusted",
+			record:        &models.PatternRecord{DayOfWeek: 1, HourOfDay: 0, AvgCPU: 85, CPUStdDev: 4, SampleCount: 4},
+			expectCPU:     85,
+			minConfidence: 0.9,
+			maxConfidence: 1,
+		},
+		{
+			name:          "single noisy week is not trusted",
+			record:        &models.PatternRecord{DayOfWeek: 1, HourOfDay: 0, AvgCPU: 85, CPUStdDev: 30, SampleCount: 1},
+			expectCPU:     85,
+			minConfidence: 0,
+			maxConfidence: 0.3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &fakePatternSource{records: map[[2]int]*models.PatternRecord{}}
+			if tt.record != nil {
+				source.records[[2]int{tt.record.DayOfWeek, tt.record.HourOfDay}] = tt.record
+			}
+			p := predictor.NewPatternMatching(source, 15*time.Minute)
+
+			prediction, err := p.Predict(context.Background(), predictor.Input{ClusterID: "test-cluster", Timestamp: now})
+
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectCPU, prediction.PredictedCPU)
+			assert.GreaterOrEqual(t, prediction.Confidence, tt.minConfidence)
+			assert.LessOrEqual(t, prediction.Confidence, tt.maxConfidence)
+			assert.Equal(t, predictor.TypePatternMatching, prediction.ModelVersion)
+		})
+	}
+}
+
+func TestLinearTrend_Predict(t *testing.T) {
+	now := time.Now()
+	start := now.Add(-10 * time.Minute)
+
+	tests := []struct {
+		name        string
+		history     []analyzer.Snapshot
+		expectErr   error
+		expectAbove float64
+		expectBelow float64
+	}{
+		{
+			name:      "insufficient history",
+			history:   buildHistory(start, time.Minute, 40, 45),
+			expectErr: predictor.ErrInsufficientData,
+		},
+		{
+			name:        "rising history forecasts higher CPU",
+			history:     buildHistory(start, time.Minute, 30, 35, 40, 45, 50, 55, 60, 65, 70, 75),
+			expectAbove: 75,
+			expectBelow: 101,
+		},
+		{
+			name:        "falling history forecasts lower CPU",
+			history:     buildHistory(start, time.Minute, 80, 75, 70, 65, 60, 55, 50, 45, 40, 35),
+			expectAbove: -1,
+			expectBelow: 35,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := predictor.NewLinearTrend(5 * time.Minute)
+
+			prediction, err := p.Predict(context.Background(), predictor.Input{
+				ClusterID: "test-cluster",
+				Timestamp: now,
+				History:   tt.history,
+			})
+
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Greater(t, prediction.PredictedCPU, tt.expectAbove)
+			assert.Less(t, prediction.PredictedCPU, tt.expectBelow)
+			assert.InDelta(t, 0.5, prediction.Confidence, 0.5)
+			assert.Equal(t, now.Add(5*time.Minute), prediction.ForecastTime)
+			assert.Equal(t, predictor.TypeLinearTrend, prediction.ModelVersion)
+		})
+	}
+}
+
+func TestHoltWinters_Predict(t *testing.T) {
+	start := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	step := time.Minute
+
+	t.Run("insufficient history", func(t *testing.T) {
+		p := predictor.NewHoltWinters(predictor.SmoothingConfig{}, 5*time.Minute)
+		_, err := p.Predict(context.Background(), predictor.Input{
+			History: buildHistory(start, step, 40, 41, 42),
+		})
+		assert.ErrorIs(t, err, predictor.ErrInsufficientData)
+	})
+
+	t.Run("follows a steady trend", func(t *testing.T) {
+		p := predictor.NewHoltWinters(predictor.SmoothingConfig{Damping: 1}, 5*time.Minute)
+		history := buildHistory(start, step, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58)
+		last := history[len(history)-1].Timestamp
+
+		prediction, err := p.Predict(context.Background(), predictor.Input{
+			ClusterID: "cluster-1",
+			Timestamp: last,
+			History:   history,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, predictor.TypeHoltWinters, prediction.ModelVersion)
+		assert.InDelta(t, 68, prediction.PredictedCPU, 0.5)
+		assert.LessOrEqual(t, prediction.LowerBound, prediction.PredictedCPU)
+		assert.GreaterOrEqual(t, prediction.UpperBound, prediction.PredictedCPU)
+		assert.Greater(t, prediction.Confidence, 0.9)
+	})
+
+	t.Run("noisy history widens the interval", func(t *testing.T) {
+		p := predictor.NewHoltWinters(predictor.SmoothingConfig{}, 5*time.Minute)
+		history := buildHistory(start, step, 20, 80, 25, 75, 30, 85, 20, 70, 35, 90)
+		last := history[len(history)-1].Timestamp
+
+		prediction, err := p.Predict(context.Background(), predictor.Input{
+			Timestamp: last,
+			History:   history,
+		})
+		require.NoError(t, err)
+
+		assert.Greater(t, prediction.UpperBound-prediction.LowerBound, 40.0)
+		assert.Less(t, prediction.Confidence, 0.3)
+	})
+
+	t.Run("seasonal component repeats the cycle", func(t *testing.T) {
+		p := predictor.NewHoltWinters(predictor.SmoothingConfig{SeasonLength: 4, Damping: 1}, time.Minute)
+		history := buildHistory(start, step, 30, 60, 30, 10, 30, 60, 30, 10, 30, 60, 30, 10)
+		last := history[len(history)-1].Timestamp
+
+		prediction, err := p.Predict(context.Background(), predictor.Input{
+			Timestamp: last,
+			History:   history,
+		})
+		require.NoError(t, err)
+
+		assert.InDelta(t, 30, prediction.PredictedCPU, 3)
+	})
+}
+
+type fakeBackfiller struct {
+	calls chan time.Duration
+}
+
+func (f *fakeBackfiller) BackfillActuals(ctx context.Context, window time.Duration, before time.Time) (int64, error) {
+	f.calls <- window
+	return 1, nil
+}
+
+func TestAccuracyTracker_Backfills(t *testing.T) {
+	backfiller := &fakeBackfiller{calls: make(chan time.Duration, 10)}
+	tracker := predictor.NewAccuracyTracker(predictor.BackfillConfig{
+		Predictions: backfiller,
+		Interval:    10 * time.Millisecond,
+		Window:      30 * time.Second,
+	})
+
+	tracker.Start()
+	defer tracker.Stop()
+
+	select {
+	case window := <-backfiller.calls:
+		assert.Equal(t, 30*time.Second, window)
+	case <-time.After(time.Second):
+		t.Fatal("expected a backfill run")
+	}
+}
+
+type fixedPredictor struct {
+	name       string
+	cpu        float64
+	confidence float64
+}
+
+func (f *fixedPredictor) Name() string {
+	return f.name
+}
+
+func (f *fixedPredictor) Predict(ctx context.Context, in predictor.Input) (*models.Prediction, error) {
+	prediction := models.NewPrediction(in.ClusterID, in.Timestamp.Add(time.Minute), f.cpu, f.confidence)
+	prediction.ModelVersion = f.name
+	return prediction, nil
+}
+
+func TestEnsemble_Predict(t *testing.T) {
+	start := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("agreeing models keep their confidence", func(t *testing.T) {
+		ensemble := predictor.NewEnsemble([]predictor.Predictor{
+			&fixedPredictor{name: "a", cpu: 70, confidence: 0.9},
+			&fixedPredictor{name: "b", cpu: 70, confidence: 0.9},
+		})
+
+		prediction, err := ensemble.Predict(context.Background(), predictor.Input{Timestamp: start})
+		require.NoError(t, err)
+
+		assert.Equal(t, predictor.TypeEnsemble, prediction.ModelVersion)
+		assert.InDelta(t, 70, prediction.PredictedCPU, 0.001)
+		assert.InDelta(t, 0.9, prediction.Confidence, 0.001)
+		require.Len(t, prediction.Components, 2)
+		assert.InDelta(t, 0.5, prediction.Components[0].Weight, 0.001)
+	})
+
+	t.Run("disagreeing models lower confidence", func(t *testing.T) {
+		ensemble := predictor.NewEnsemble([]predictor.Predictor{
+			&fixedPredictor{name: "a", cpu: 40, confidence: 0.9},
+			&fixedPredictor{name: "b", cpu: 80, confidence: 0.9},
+		})
+
+		prediction, err := ensemble.Predict(context.Background(), predictor.Input{Timestamp: start})
+		require.NoError(t, err)
+
+		assert.InDelta(t, 60, prediction.PredictedCPU, 0.001)
+		assert.Less(t, prediction.Confidence, 0.1)
+	})
+
+	t.Run("accurate model gains weight", func(t *testing.T) {
+		ensemble := predictor.NewEnsemble([]predictor.Predictor{
+			&fixedPredictor{name: "accurate", cpu: 50, confidence: 0.8},
+			&fixedPredictor{name: "wrong", cpu: 90, confidence: 0.8},
+		})
+
+		now := start
+		var prediction *models.Prediction
+		for i := 0; i < 5; i++ {
+			var err error
+			prediction, err = ensemble.Predict(context.Background(), predictor.Input{
+				Timestamp: now,
+				Current:   &models.AnalyzedMetrics{AvgCPU: 50},
+			})
+			require.NoError(t, err)
+			now = now.Add(time.Minute)
+		}
+
+		assert.Greater(t, prediction.Components[0].Weight, 0.9)
+		assert.Less(t, prediction.PredictedCPU, 55.0)
+	})
+}
@@ -0,0 +1,266 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OldStager01/cloud-autoscaler/internal/decision"
+	"github.com/OldStager01/cloud-autoscaler/internal/events"
+	"github.com/OldStager01/cloud-autoscaler/internal/schedule"
+	"github.com/OldStager01/cloud-autoscaler/pkg/models"
+)
+
+func TestCron_Parse(t *testing.T) {
+	valid := []string{"* * * * *", "*/15 8-18 * * MON-FRI", "0 0 1,15 * *", "30 2 * jan-mar 7", "@daily"}
+	for _, expr := range valid {
+		_, err := schedule.Parse(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "* * * * funday"}
+	for _, expr := range invalid {
+		_, err := schedule.Parse(expr)
+		assert.ErrorIs(t, err, schedule.ErrInvalidCron, expr)
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	from := time.Date(2026, 3, 6, 17, 30, 0, 0, time.UTC) // Friday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 3, 6, 17, 45, 0, 0, time.UTC)},
+		{"0 8 * * MON-FRI", time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		// Restricted day-of-month and day-of-week match on either
+		{"0 12 10 * 0", time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		c, err := schedule.Parse(tt.expr)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, c.Next(from), tt.expr)
+	}
+
+	never, err := schedule.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(from).IsZero())
+}
+
+func TestCron_ActiveWindow(t *testing.T) {
+	c, err := schedule.Parse("0 8 * * *")
+	require.NoError(t, err)
+
+	start, ok := c.ActiveWindow(time.Date(2026, 3, 6, 9, 30, 0, 0, time.UTC), 2*time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 3, 6, 8, 0, 0, 0, time.UTC), start)
+
+	_, ok = c.ActiveWindow(time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC), 2*time.Hour)
+	assert.False(t, ok)
+}
+
+type fakeScheduleSource struct {
+	schedules []*models.ScalingSchedule
+}
+
+func (f *fakeScheduleSource) GetEnabled(ctx context.Context) ([]*models.ScalingSchedule, error) {
+	return f.schedules, nil
+}
+
+type fakeOverrideTarget struct {
+	overrides map[string]decision.Override
+}
+
+func (f *fakeOverrideTarget) ApplyOverride(clusterID string, override decision.Override) bool {
+	f.overrides[override.ID] = override
+	return true
+}
+
+func (f *fakeOverrideTarget) ClearOverride(clusterID, overrideID string) {
+	delete(f.overrides, overrideID)
+}
+
+func TestScheduler_Evaluate(t *testing.T) {
+	minServers := 8
+	s := models.NewScalingSchedule("cluster-1", "morning-peak")
+	s.Cron = "0 8 * * *"
+	s.Timezone = "America/New_York"
+	s.DurationMinutes = 120
+	s.MinServers = &minServers
+	require.NoError(t, s.Validate())
+
+	bus := events.NewEventBus(10)
+	defer bus.Close()
+	started := bus.Subscribe(models.EventTypeScheduleStarted)
+	ended := bus.Subscribe(models.EventTypeScheduleEnded)
+
+	source := &fakeScheduleSource{schedules: []*models.ScalingSchedule{s}}
+	target := &fakeOverrideTarget{overrides: make(map[string]decision.Override)}
+	scheduler := schedule.NewScheduler(schedule.Config{
+		Schedules: source,
+		Target:    target,
+		Publisher: events.NewPublisher(bus),
+	})
+
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	scheduler.Evaluate(time.Date(2026, 3, 6, 7, 59, 0, 0, loc))
+	assert.Empty(t, target.overrides)
+
+	scheduler.Evaluate(time.Date(2026, 3, 6, 8, 30, 0, 0, loc))
+	require.Contains(t, target.overrides, s.ID)
+	assert.Equal(t, 8, *target.overrides[s.ID].MinServers)
+	assert.Len(t, started, 1)
+
+	// Re-applied without announcing the window again
+	scheduler.Evaluate(time.Date(2026, 3, 6, 9, 0, 0, 0, loc))
+	assert.Len(t, started, 1)
+
+	scheduler.Evaluate(time.Date(2026, 3, 6, 10, 0, 0, 0, loc))
+	assert.Empty(t, target.overrides)
+	assert.Len(t, ended, 1)
+}
+
+func TestScalingSchedule_Validate(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	base := func() *models.ScalingSchedule {
+		s := models.NewScalingSchedule("cluster-1", "nightly")
+		s.Cron = "0 2 * * *"
+		s.DurationMinutes = 60
+		s.MaxServers = intPtr(4)
+		return s
+	}
+
+	assert.NoError(t, base().Validate())
+
+	s := base()
+	s.Timezone = "Mars/Olympus_Mons"
+	assert.Error(t, s.Validate())
+
+	s = base()
+	s.MaxServers = nil
+	assert.Error(t, s.Validate())
+
+	s = base()
+	s.DesiredServers = intPtr(3)
+	assert.Error(t, s.Validate())
+
+	s = base()
+	s.MinServers = intPtr(6)
+	assert.Error(t, s.Validate())
+}
+
+type fakeFreezeSource struct {
+	freezes []*models.FreezeWindow
+}
+
+func (f *fakeFreezeSource) GetEnabledForCluster(ctx context.Context, clusterID string) ([]*models.FreezeWindow, error) {
+	return f.freezes, nil
+}
+
+func TestFreezeChecker_ActiveFreezes(t *testing.T) {
+	start := time.Date(2026, 3, 6, 22, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	oneOff := models.NewFreezeWindow(1, "release")
+	oneOff.StartsAt = &start
+	oneOff.EndsAt = &end
+	require.NoError(t, oneOff.Validate())
+
+	weekly := models.NewFreezeWindow(1, "db-migration")
+	weekly.Scope = models.FreezeScaleDown
+	weekly.Cron = "0 9 * * FRI"
+	weekly.Timezone = "Europe/Berlin"
+	weekly.DurationMinutes = 90
+	require.NoError(t, weekly.Validate())
+
+	checker := schedule.NewFreezeChecker(&fakeFreezeSource{
+		freezes: []*models.FreezeWindow{oneOff, weekly},
+	})
+
+	active, err := checker.ActiveFreezes(context.Background(), "cluster-1", start.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	active, err = checker.ActiveFreezes(context.Background(), "cluster-1", start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "release", active[0].Name)
+	assert.Equal(t, end, active[0].ActiveUntil)
+
+	active, err = checker.ActiveFreezes(context.Background(), "cluster-1", end)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	// 2026-03-06 is a Friday; 09:30 in Berlin is 08:30 UTC
+	active, err = checker.ActiveFreezes(context.Background(), "cluster-1", time.Date(2026, 3, 6, 8, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "db-migration", active[0].Name)
+	assert.Equal(t, time.Date(2026, 3, 6, 9, 30, 0, 0, time.UTC), active[0].ActiveUntil.UTC())
+}
+
+func TestFreezeWindow_Validate(t *testing.T) {
+	start := time.Now()
+	end := start.Add(time.Hour)
+
+	base := func() *models.FreezeWindow {
+		f := models.NewFreezeWindow(1, "deploy")
+		f.StartsAt = &start
+		f.EndsAt = &end
+		return f
+	}
+
+	assert.NoError(t, base().Validate())
+
+	f := base()
+	f.Scope = "scale_up"
+	assert.Error(t, f.Validate())
+
+	f = base()
+	f.EndsAt = nil
+	assert.Error(t, f.Validate())
+
+	f = base()
+	f.EndsAt = &start
+	assert.Error(t, f.Validate())
+
+	// One-off and recurring at once
+	f = base()
+	f.Cron = "0 2 * * *"
+	f.DurationMinutes = 60
+	assert.Error(t, f.Validate())
+
+	f = models.NewFreezeWindow(1, "nightly")
+	f.Cron = "0 2 * * *"
+	assert.Error(t, f.Validate())
+	f.DurationMinutes = 60
+	assert.NoError(t, f.Validate())
+	f.Timezone = "Mars/Olympus_Mons"
+	assert.Error(t, f.Validate())
+}
+
+func TestFreezeWindow_Blocks(t *testing.T) {
+	all := models.NewFreezeWindow(1, "deploy")
+	assert.True(t, all.Blocks(models.ActionScaleDown, false))
+	assert.True(t, all.Blocks(models.ActionScaleUp, false))
+	assert.True(t, all.Blocks(models.ActionScaleUp, true))
+	assert.False(t, all.Blocks(models.ActionMaintain, false))
+
+	all.AllowEmergency = true
+	assert.False(t, all.Blocks(models.ActionScaleUp, true))
+	assert.True(t, all.Blocks(models.ActionScaleUp, false))
+
+	downOnly := models.NewFreezeWindow(1, "migration")
+	downOnly.Scope = models.FreezeScaleDown
+	assert.True(t, downOnly.Blocks(models.ActionScaleDown, false))
+	assert.False(t, downOnly.Blocks(models.ActionScaleUp, false))
+}